@@ -0,0 +1,243 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// validConfig returns the smallest configuration Validate accepts
+func validConfig() *Config {
+	return &Config{MonitorKeywords: []KeywordConfig{{Keyword: "acme"}}}
+}
+
+func TestValidateAttachedReportRedaction(t *testing.T) {
+	tests := []struct {
+		name            string
+		reportRedaction string
+		emailRedaction  string
+		redact          bool
+		wantErr         bool
+	}{
+		{"defaults mail raw reports", "", "", false, true},
+		{"reports partial, email partial", "partial", "", false, false},
+		{"reports.redact shorthand", "", "", true, false},
+		{"reports partial, email full", "partial", "full", false, true},
+		{"reports full, email partial", "full", "partial", false, false},
+		{"nothing redacted", "none", "none", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			cfg.Email.AttachReports = true
+			cfg.Reports.Redact = tt.redact
+			cfg.DeepScan.ReportRedaction = tt.reportRedaction
+			cfg.DeepScan.EmailRedaction = tt.emailRedaction
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "attach_reports") {
+				t.Errorf("error %q doesn't name attach_reports", err)
+			}
+		})
+	}
+}
+
+func TestValidateAllowsUnredactedReportsWithoutAttachments(t *testing.T) {
+	cfg := validConfig()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+}
+
+func TestLoadConfigExpandsEnv(t *testing.T) {
+	t.Setenv("TEST_SMTP_PASSWORD", "p#ss: \"word\"\nnext_key: injected")
+	t.Setenv("TEST_SMTP_PORT", "2525")
+	t.Setenv("TEST_COMMENTED", "unused")
+
+	tests := []struct {
+		name     string
+		file     string
+		contents string
+	}{
+		{"yaml", "config.yaml", `
+monitor_keywords: [acme]
+email:
+  smtp_host: smtp.example.com # ${TEST_COMMENTED}
+  smtp_port: ${TEST_SMTP_PORT}
+  password: ${TEST_SMTP_PASSWORD}
+  from: "$UNSET_TEST_VARIABLE"
+  to: [security@example.com]
+`},
+		{"json", "config.json", `{
+  "monitor_keywords": ["acme"],
+  "email": {"smtp_host": "smtp.example.com", "smtp_port": 2525, "password": "${TEST_SMTP_PASSWORD}", "from": "$UNSET_TEST_VARIABLE", "to": ["security@example.com"]}
+}`},
+		{"toml", "config.toml", `
+monitor_keywords = ["acme"]
+
+[email]
+smtp_host = "smtp.example.com"
+smtp_port = 2525
+password = "${TEST_SMTP_PASSWORD}"
+from = "$UNSET_TEST_VARIABLE"
+to = ["security@example.com"]
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.contents), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := LoadConfig(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Email.Password != os.Getenv("TEST_SMTP_PASSWORD") {
+				t.Errorf("password = %q, want the variable's value verbatim", cfg.Email.Password)
+			}
+			if cfg.Email.SMTPPort != 2525 {
+				t.Errorf("smtp_port = %d, want 2525", cfg.Email.SMTPPort)
+			}
+			if cfg.Email.SMTPHost != "smtp.example.com" {
+				t.Errorf("smtp_host = %q", cfg.Email.SMTPHost)
+			}
+			if cfg.Email.From != "$UNSET_TEST_VARIABLE" {
+				t.Errorf("from = %q, want the unset reference as written", cfg.Email.From)
+			}
+		})
+	}
+}
+
+func TestValidateLoadsPatternFilesOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gitleaks.toml")
+	rules := "[[rules]]\nid = \"acme-api-key\"\nregex = '''acme_live_[a-z0-9]{24}'''\n"
+	if err := os.WriteFile(path, []byte(rules), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := validConfig()
+	cfg.DeepScan.PatternFiles = []string{path}
+	for i := 0; i < 2; i++ {
+		if err := cfg.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := cfg.LoadedPatternFiles()
+	if len(files) != 1 || files[0].Path != path || len(files[0].Patterns) != 1 {
+		t.Fatalf("LoadedPatternFiles() = %+v, want the one file with its rule", files)
+	}
+}
+
+func TestValidateAlertOnVerifiedActive(t *testing.T) {
+	enabled, disabled := true, false
+	tests := []struct {
+		name          string
+		deepScan      bool
+		verifySecrets bool
+		keyword       KeywordConfig
+		alertOn       string
+		wantErr       bool
+	}{
+		{"verification on", true, true, KeywordConfig{Keyword: "acme"}, AlertOnVerifiedActive, false},
+		{"verification off", true, false, KeywordConfig{Keyword: "acme"}, AlertOnVerifiedActive, true},
+		{"deep scan off", false, true, KeywordConfig{Keyword: "acme"}, AlertOnVerifiedActive, true},
+		{"keyword verifies", true, false, KeywordConfig{Keyword: "acme", Verify: &enabled}, AlertOnVerifiedActive, false},
+		{"keyword deep scans", false, true, KeywordConfig{Keyword: "acme", DeepScan: &enabled}, AlertOnVerifiedActive, false},
+		{"keyword doesn't verify", true, true, KeywordConfig{Keyword: "acme", Verify: &disabled}, AlertOnVerifiedActive, true},
+		{"secrets without verification", true, false, KeywordConfig{Keyword: "acme"}, AlertOnSecrets, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{MonitorKeywords: []KeywordConfig{tt.keyword}}
+			cfg.DeepScan.Enabled = tt.deepScan
+			cfg.DeepScan.VerifySecrets = tt.verifySecrets
+			cfg.Monitoring.AlertOn = tt.alertOn
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "verify_secrets") {
+				t.Errorf("error %q doesn't name verify_secrets", err)
+			}
+		})
+	}
+}
+
+func TestLoadConfigMaxRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     int
+		wantErr  bool
+	}{
+		{"default", "monitor_keywords: [acme]\n", DefaultMaxRetries, false},
+		{"no retries", "monitor_keywords: [acme]\npostman:\n  max_retries: 0\n", 0, false},
+		{"set", "monitor_keywords: [acme]\npostman:\n  max_retries: 5\n", 5, false},
+		{"negative", "monitor_keywords: [acme]\npostman:\n  max_retries: -1\n", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.contents), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := LoadConfig(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("LoadConfig() = nil, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Postman.MaxRetries != tt.want {
+				t.Errorf("max_retries = %d, want %d", cfg.Postman.MaxRetries, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigRateLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     int
+		wantErr  bool
+	}{
+		{"default", "monitor_keywords: [acme]\n", 2, false},
+		{"set", "monitor_keywords: [acme]\npostman:\n  rate_limit_per_second: 10\n", 10, false},
+		{"maximum", "monitor_keywords: [acme]\npostman:\n  rate_limit_per_second: 1000\n", MaxRateLimitPerSecond, false},
+		{"too fast", "monitor_keywords: [acme]\npostman:\n  rate_limit_per_second: 2000000000\n", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.contents), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := LoadConfig(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("LoadConfig() = nil, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Postman.RateLimitPerSecond != tt.want {
+				t.Errorf("rate_limit_per_second = %d, want %d", cfg.Postman.RateLimitPerSecond, tt.want)
+			}
+		})
+	}
+}
@@ -72,6 +72,16 @@ func GetEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// GetEnvFloat gets a floating-point environment variable with a fallback default
+func GetEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 // GetEnvBool gets a boolean environment variable with a fallback default
 func GetEnvBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
@@ -99,25 +109,132 @@ func GetEnvSlice(key string, defaultValue []string) []string {
 	return defaultValue
 }
 
+// GetEnvBoolMap gets a comma-separated list of name=bool pairs (e.g. "github=true,stripe=false")
+// as a map; entries that don't parse are skipped
+func GetEnvBoolMap(key string) map[string]bool {
+	result := make(map[string]bool)
+	for _, entry := range GetEnvSlice(key, nil) {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+		if boolValue, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil {
+			result[strings.TrimSpace(name)] = boolValue
+		}
+	}
+	return result
+}
+
+// getEnvPattern gets an environment variable holding one regular expression. It isn't split
+// on commas like GetEnvSlice, as they appear in {m,n} quantifiers: several patterns are
+// joined with |.
+func getEnvPattern(key string) []string {
+	if value := strings.TrimSpace(os.Getenv(key)); value != "" {
+		return []string{value}
+	}
+	return []string{}
+}
+
 // LoadConfigFromEnv loads configuration from environment variables
 func LoadConfigFromEnv() (*Config, error) {
+	useWebScraper := GetEnvBool("USE_WEB_SCRAPER", true)
+	scanCollectionJSON := GetEnvBool("SCAN_COLLECTION_JSON", false)
+
 	cfg := &Config{
 		PostmanAPIKey: GetEnv("POSTMAN_API_KEY", ""),
+		Postman: PostmanConfig{
+			MaxRetries:         GetEnvInt("POSTMAN_MAX_RETRIES", DefaultMaxRetries),
+			RateLimitPerSecond: GetEnvInt("POSTMAN_RATE_LIMIT_PER_SECOND", 2),
+			DisableRateLimit:   GetEnvBool("POSTMAN_DISABLE_RATE_LIMIT", false),
+			Proxy: ProxyConfig{
+				URL:      GetEnv("POSTMAN_PROXY_URL", ""),
+				Username: GetEnv("POSTMAN_PROXY_USERNAME", ""),
+				Password: GetEnv("POSTMAN_PROXY_PASSWORD", ""),
+				CAFile:   GetEnv("POSTMAN_PROXY_CA_FILE", ""),
+			},
+		},
 		Email: EmailConfig{
 			SMTPHost: GetEnv("SMTP_HOST", ""),
 			SMTPPort: GetEnvInt("SMTP_PORT", 587),
 			From:     GetEnv("SMTP_FROM", ""),
 			Password: GetEnv("SMTP_PASSWORD", ""),
 			To:       GetEnvSlice("SMTP_TO", []string{}),
+
+			MinIntervalMinutes: GetEnvInt("SMTP_MIN_INTERVAL_MINUTES", 0),
+			InsecureSkipVerify: GetEnvBool("SMTP_INSECURE_SKIP_VERIFY", false),
+			AttachReports:      GetEnvBool("SMTP_ATTACH_REPORTS", false),
+			MaxAttachmentKB:    GetEnvInt("SMTP_MAX_ATTACHMENT_KB", 10240),
+		},
+		Discord: DiscordConfig{
+			WebhookURL: GetEnv("DISCORD_WEBHOOK_URL", ""),
 		},
 		Monitoring: MonitoringConfig{
-			IntervalHours: GetEnvInt("MONITOR_INTERVAL_HOURS", 24),
+			IntervalHours:    GetEnvInt("MONITOR_INTERVAL_HOURS", 24),
+			Schedule:         GetEnv("MONITOR_SCHEDULE", ""),
+			UseWebScraper:    &useWebScraper,
+			ScrapeMaxResults: GetEnvInt("SCRAPE_MAX_RESULTS", 200),
+			AlertOn:          GetEnv("ALERT_ON", "all"),
 		},
 		DeepScan: DeepScanConfig{
-			Enabled:       GetEnvBool("DEEP_SCAN_ENABLED", true),
-			VerifySecrets: GetEnvBool("VERIFY_SECRETS", true),
+			Enabled:             GetEnvBool("DEEP_SCAN_ENABLED", true),
+			VerifySecrets:       GetEnvBool("VERIFY_SECRETS", true),
+			VerifyConnectivity:  GetEnvBool("VERIFY_CONNECTIVITY", false),
+			VerifyPyPIUpload:    GetEnvBool("VERIFY_PYPI_UPLOAD", false),
+			VerifyWorkers:       GetEnvInt("VERIFY_WORKERS", 4),
+			VerifyMaxAttempts:   GetEnvInt("VERIFY_MAX_ATTEMPTS", 3),
+			VerifyRetryDelayMs:  GetEnvInt("VERIFY_RETRY_DELAY_MS", 500),
+			VerifyBudgetMinutes: GetEnvInt("VERIFY_BUDGET_MINUTES", 0),
+			MaxVerifications:    GetEnvInt("MAX_VERIFICATIONS", 0),
+			VerifyTypes:         GetEnvBoolMap("VERIFY_TYPES"),
+			VerifyProxy: ProxyConfig{
+				URL:      GetEnv("VERIFY_PROXY_URL", ""),
+				Username: GetEnv("VERIFY_PROXY_USERNAME", ""),
+				Password: GetEnv("VERIFY_PROXY_PASSWORD", ""),
+				CAFile:   GetEnv("VERIFY_PROXY_CA_FILE", ""),
+			},
+			RequireVerifyProxy:       GetEnvBool("REQUIRE_VERIFY_PROXY", false),
+			VerifyCacheHours:         GetEnvInt("VERIFY_CACHE_HOURS", 24),
+			DisableVerifyCache:       GetEnvBool("DISABLE_VERIFY_CACHE", false),
+			RateLimitRetrySeconds:    GetEnvInt("RATE_LIMIT_RETRY_SECONDS", 60),
+			RateLimitMaxWaitSeconds:  GetEnvInt("RATE_LIMIT_MAX_WAIT_SECONDS", 300),
+			AuditLog:                 GetEnv("VERIFY_AUDIT_LOG", ""),
+			DryVerify:                GetEnvBool("DRY_VERIFY", false),
+			PlaceholderWords:         GetEnvSlice("PLACEHOLDER_WORDS", []string{}),
+			DropPossiblePlaceholders: GetEnvBool("DROP_POSSIBLE_PLACEHOLDERS", false),
+			ReportRedaction:          GetEnv("REPORT_REDACTION", ""),
+			EmailRedaction:           GetEnv("EMAIL_REDACTION", ""),
+			MinEntropy:               GetEnvFloat("MIN_ENTROPY", 3.5),
+			MinConfidence:            GetEnv("MIN_CONFIDENCE", ""),
+			PatternFiles:             GetEnvSlice("PATTERN_FILES", []string{}),
+			Workers:                  GetEnvInt("SCAN_WORKERS", 0),
+			ScanCollectionJSON:       &scanCollectionJSON,
+			MaxExampleBodyKB:         GetEnvInt("MAX_EXAMPLE_BODY_KB", 1024),
+			Allowlist: AllowlistConfig{
+				Values:   GetEnvSlice("ALLOWLIST_VALUES", []string{}),
+				Patterns: getEnvPattern("ALLOWLIST_PATTERNS"),
+			},
+		},
+		Metrics: MetricsConfig{
+			Addr: GetEnv("METRICS_ADDR", ""),
+		},
+		Health: HealthConfig{
+			Addr: GetEnv("HEALTH_ADDR", ""),
+		},
+		Reports: ReportsConfig{
+			Dir:     GetEnv("REPORTS_DIR", "reports"),
+			Prefix:  GetEnv("REPORTS_PREFIX", ""),
+			Redact:  GetEnvBool("REPORTS_REDACT", false),
+			Formats: GetEnvSlice("REPORTS_FORMATS", []string{}),
+		},
+		State: StateConfig{
+			Dir: GetEnv("STATE_DIR", "state"),
+		},
+		Timeouts: TimeoutsConfig{
+			Postman: GetEnvInt("POSTMAN_TIMEOUT_SECONDS", 30),
+			Verify:  GetEnvInt("VERIFY_TIMEOUT_SECONDS", 10),
+			Scrape:  GetEnvInt("SCRAPE_TIMEOUT_SECONDS", 30),
 		},
-		MonitorKeywords: GetEnvSlice("MONITOR_KEYWORDS", []string{}),
+		MonitorKeywords: Keywords(GetEnvSlice("MONITOR_KEYWORDS", []string{})),
 		IgnoreKeywords:  GetEnvSlice("IGNORE_KEYWORDS", []string{"example", "demo", "test", "sample", "tutorial"}),
 	}
 
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadConfigFromEnvAllowlist(t *testing.T) {
+	tests := []struct {
+		name         string
+		values       string
+		patterns     string
+		wantValues   []string
+		wantPatterns []string
+	}{
+		{"unset", "", "", []string{}, []string{}},
+		{"values are comma-separated", "demo_key_1, demo_key_2", "", []string{"demo_key_1", "demo_key_2"}, []string{}},
+		{"quantifier commas are kept", "", `^sk_demo_[a-z0-9]{8,12}$`, []string{}, []string{`^sk_demo_[a-z0-9]{8,12}$`}},
+		{"alternation", "", ` ^pk_test_|^sk_demo_ `, []string{}, []string{`^pk_test_|^sk_demo_`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MONITOR_KEYWORDS", "acme")
+			t.Setenv("ALLOWLIST_VALUES", tt.values)
+			t.Setenv("ALLOWLIST_PATTERNS", tt.patterns)
+
+			cfg, err := LoadConfigFromEnv()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := cfg.DeepScan.Allowlist.Values; !reflect.DeepEqual(got, tt.wantValues) {
+				t.Errorf("values = %q, want %q", got, tt.wantValues)
+			}
+			if got := cfg.DeepScan.Allowlist.Patterns; !reflect.DeepEqual(got, tt.wantPatterns) {
+				t.Errorf("patterns = %q, want %q", got, tt.wantPatterns)
+			}
+		})
+	}
+}
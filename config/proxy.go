@@ -0,0 +1,100 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// ProxyConfig routes outbound HTTP traffic through a proxy. Without a URL the standard
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables apply.
+type ProxyConfig struct {
+	URL      string `yaml:"url"`      // http://, https:// or socks5:// proxy address
+	Username string `yaml:"username"` // Optional basic auth, sent as Proxy-Authorization
+	Password string `yaml:"password"`
+
+	// CAFile adds PEM CA certificates to the trusted roots, for TLS-intercepting proxies
+	CAFile string `yaml:"ca_file"`
+}
+
+// Transport builds an HTTP transport that uses the proxy and trusts its CA file
+func (p ProxyConfig) Transport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if p.URL != "" {
+		proxyURL, err := p.parseURL()
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if p.CAFile != "" {
+		pem, err := os.ReadFile(p.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read proxy CA file: %w", err)
+		}
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates in proxy CA file %s", p.CAFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: roots}
+	}
+
+	return transport, nil
+}
+
+// Address returns the host:port HTTPS requests are sent through - the configured proxy, or
+// the one from the environment - or "" when they go out directly
+func (p ProxyConfig) Address() string {
+	var proxyURL *url.URL
+	if p.URL != "" {
+		proxyURL, _ = p.parseURL()
+	} else {
+		probe, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+		proxyURL, _ = http.ProxyFromEnvironment(probe)
+	}
+	if proxyURL == nil {
+		return ""
+	}
+
+	if proxyURL.Port() != "" {
+		return proxyURL.Host
+	}
+	port := "80"
+	switch proxyURL.Scheme {
+	case "https":
+		port = "443"
+	case "socks5", "socks5h":
+		port = "1080"
+	}
+	return net.JoinHostPort(proxyURL.Hostname(), port)
+}
+
+// parseURL parses the proxy URL, adding the basic auth credentials
+func (p ProxyConfig) parseURL() (*url.URL, error) {
+	proxyURL, err := url.Parse(p.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q (use http, https or socks5)", proxyURL.Scheme)
+	}
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("proxy URL %q has no host", p.URL)
+	}
+
+	if p.Username != "" {
+		proxyURL.User = url.UserPassword(p.Username, p.Password)
+	}
+	return proxyURL, nil
+}
@@ -0,0 +1,164 @@
+package config
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// connectProxy is a test proxy tunnelling CONNECT requests, recording their targets and
+// Proxy-Authorization headers
+type connectProxy struct {
+	*httptest.Server
+	mu       sync.Mutex
+	connects []string
+	auth     []string
+}
+
+func newConnectProxy(t *testing.T) *connectProxy {
+	t.Helper()
+	p := &connectProxy{}
+	p.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			http.Error(w, "CONNECT only", http.StatusMethodNotAllowed)
+			return
+		}
+		p.mu.Lock()
+		p.connects = append(p.connects, r.Host)
+		p.auth = append(p.auth, r.Header.Get("Proxy-Authorization"))
+		p.mu.Unlock()
+
+		upstream, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		client, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			upstream.Close()
+			return
+		}
+		client.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		go func() {
+			io.Copy(upstream, client)
+			upstream.Close()
+		}()
+		io.Copy(client, upstream)
+		client.Close()
+	}))
+	t.Cleanup(p.Close)
+	return p
+}
+
+// writeCertificate saves a test server's certificate as a PEM CA file
+func writeCertificate(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestProxyTransport(t *testing.T) {
+	target := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer target.Close()
+	targetHost := strings.TrimPrefix(target.URL, "https://")
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		wantAuth string
+	}{
+		{"anonymous", "", "", ""},
+		{"basic auth", "probe", "s3cret", "Basic " + base64.StdEncoding.EncodeToString([]byte("probe:s3cret"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxy := newConnectProxy(t)
+			transport, err := ProxyConfig{
+				URL:      proxy.URL,
+				Username: tt.username,
+				Password: tt.password,
+				CAFile:   writeCertificate(t, target),
+			}.Transport()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := (&http.Client{Transport: transport}).Get(target.URL)
+			if err != nil {
+				t.Fatalf("request through the proxy, trusting its CA file: %v", err)
+			}
+			resp.Body.Close()
+
+			proxy.mu.Lock()
+			defer proxy.mu.Unlock()
+			if len(proxy.connects) != 1 || proxy.connects[0] != targetHost {
+				t.Fatalf("proxy saw CONNECTs %q, want one to %s", proxy.connects, targetHost)
+			}
+			if proxy.auth[0] != tt.wantAuth {
+				t.Errorf("Proxy-Authorization = %q, want %q", proxy.auth[0], tt.wantAuth)
+			}
+		})
+	}
+}
+
+func TestProxyTransportErrors(t *testing.T) {
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		proxy   ProxyConfig
+		wantErr string
+	}{
+		{"unsupported scheme", ProxyConfig{URL: "ftp://proxy.internal:21"}, "unsupported proxy scheme"},
+		{"no host", ProxyConfig{URL: "http://"}, "has no host"},
+		{"missing CA file", ProxyConfig{CAFile: filepath.Join(t.TempDir(), "missing.pem")}, "failed to read proxy CA file"},
+		{"CA file without certificates", ProxyConfig{CAFile: notPEM}, "no PEM certificates"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.proxy.Transport()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Transport() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProxyAddress(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "")
+	t.Setenv("https_proxy", "")
+
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"", ""},
+		{"http://proxy.internal:3128", "proxy.internal:3128"},
+		{"http://proxy.internal", "proxy.internal:80"},
+		{"https://proxy.internal", "proxy.internal:443"},
+		{"socks5://proxy.internal", "proxy.internal:1080"},
+	}
+	for _, tt := range tests {
+		if got := (ProxyConfig{URL: tt.url}).Address(); got != tt.want {
+			t.Errorf("Address() of %q = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"github.com/yourusername/postman-observer/scanner"
+)
+
+// CustomVerifierConfig verifies one secret type against an HTTP endpoint of your own, e.g.
+// the introspection endpoint of an internal API. {{secret}} in the url, a header or the
+// body is replaced by the secret, which is sent nowhere else.
+type CustomVerifierConfig struct {
+	Method  string            `yaml:"method"` // Default GET, or POST with a body
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers"`
+	Body    string            `yaml:"body"`
+
+	// ActiveStatus and InvalidStatus are the status codes of an active (default 200) and
+	// an invalid secret (default 401 and 403); anything else is reported as an error
+	ActiveStatus  []int `yaml:"active_status"`
+	InvalidStatus []int `yaml:"invalid_status"`
+
+	// ActiveField is a JSON path, e.g. "data.active", that must be true for the secret to
+	// be active; Details maps detail names to JSON paths of values to report
+	ActiveField string            `yaml:"active_field"`
+	Details     map[string]string `yaml:"details"`
+}
+
+// Verifier builds the scanner's custom verifier, checking the request template and rules
+func (c CustomVerifierConfig) Verifier() (*scanner.CustomVerifier, error) {
+	return scanner.NewCustomVerifier(scanner.CustomVerifier{
+		Method:        c.Method,
+		URL:           c.URL,
+		Headers:       c.Headers,
+		Body:          c.Body,
+		ActiveStatus:  c.ActiveStatus,
+		InvalidStatus: c.InvalidStatus,
+		ActiveField:   c.ActiveField,
+		Details:       c.Details,
+	})
+}
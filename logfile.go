@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Defaults for log file rotation
+const (
+	defaultLogMaxSizeMB = 100
+	defaultLogMaxFiles  = 10
+)
+
+// rotatingFile is the log file sink. Once the active file would grow past maxSize it
+// starts a new timestamped file and deletes the oldest, keeping at most maxFiles log
+// files in the directory. A maxSize or maxFiles of 0 or less disables that limit.
+type rotatingFile struct {
+	dir      string
+	maxSize  int64
+	maxFiles int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// openRotatingFile creates the log directory and opens a new log file in it
+func openRotatingFile(dir string, maxSize int64, maxFiles int) (*rotatingFile, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	f := &rotatingFile{dir: dir, maxSize: maxSize, maxFiles: maxFiles}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	f.prune()
+	return f, nil
+}
+
+// Name returns the path of the active log file
+func (f *rotatingFile) Name() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Name()
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		// Keep logging to the full file rather than losing lines if a new one can't be opened
+		previous := f.file
+		if err := f.open(); err == nil {
+			previous.Close()
+			f.prune()
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the active log file
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
+
+// open starts a new log file named after the current time (includes time with AM/PM)
+func (f *rotatingFile) open() error {
+	timestamp := time.Now().Format("2006-01-02_03-04-05PM")
+	path := filepath.Join(f.dir, fmt.Sprintf("observer_%s.log", timestamp))
+	for i := 2; fileExists(path); i++ {
+		path = filepath.Join(f.dir, fmt.Sprintf("observer_%s_%d.log", timestamp, i))
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	f.file, f.size = file, 0
+	return nil
+}
+
+// prune deletes the oldest log files beyond maxFiles, never the active one
+func (f *rotatingFile) prune() {
+	if f.maxFiles <= 0 {
+		return
+	}
+
+	paths, err := filepath.Glob(filepath.Join(f.dir, "observer_*.log"))
+	if err != nil || len(paths) <= f.maxFiles {
+		return
+	}
+
+	modTimes := make(map[string]time.Time, len(paths))
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			modTimes[path] = info.ModTime()
+		}
+	}
+	// Newest first; files rotated within the same second are told apart by their suffix
+	sort.Slice(paths, func(i, j int) bool {
+		if !modTimes[paths[i]].Equal(modTimes[paths[j]]) {
+			return modTimes[paths[i]].After(modTimes[paths[j]])
+		}
+		if len(paths[i]) != len(paths[j]) {
+			return len(paths[i]) > len(paths[j])
+		}
+		return paths[i] > paths[j]
+	})
+
+	for _, path := range paths[f.maxFiles:] {
+		if path != f.file.Name() {
+			os.Remove(path)
+		}
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Log formats accepted by -log-format
+const (
+	logFormatText = "text" // Human-readable lines with emoji (default)
+	logFormatJSON = "json" // One JSON record per line for Loki/ELK
+)
+
+// setLogFormat routes log/slog calls, and the standard log package, to w in the given
+// format. In text mode the output is the usual dated lines; in JSON mode every line is a
+// record with level, time, message and, for slog calls, their fields.
+func setLogFormat(format string, w io.Writer) error {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", logFormatText:
+		slog.SetDefault(slog.New(&textHandler{w: w, mu: &sync.Mutex{}}))
+		log.SetOutput(w)
+		log.SetFlags(log.Ldate | log.Ltime)
+	case logFormatJSON:
+		handler := &trimHandler{Handler: slog.NewJSONHandler(w, nil)}
+		slog.SetDefault(slog.New(handler))
+		log.SetOutput(&logWriter{handler: handler})
+		log.SetFlags(0)
+	default:
+		return fmt.Errorf("unknown log format %q (use text or json)", format)
+	}
+	return nil
+}
+
+// textHandler writes the message alone, in the standard log package's date/time format,
+// so a line reads the same whether it was logged with log or slog. Fields are left out:
+// the messages already carry the same details.
+type textHandler struct {
+	w  io.Writer
+	mu *sync.Mutex
+}
+
+func (h *textHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= slog.LevelInfo
+}
+
+func (h *textHandler) Handle(_ context.Context, r slog.Record) error {
+	line := r.Time.Format("2006/01/02 15:04:05 ") + r.Message + "\n"
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.w, line)
+	return err
+}
+
+func (h *textHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h *textHandler) WithGroup(string) slog.Handler      { return h }
+
+// trimHandler drops the indentation used to nest human-readable lines from messages
+type trimHandler struct {
+	slog.Handler
+}
+
+func (h *trimHandler) Handle(ctx context.Context, r slog.Record) error {
+	record := slog.NewRecord(r.Time, r.Level, strings.TrimSpace(r.Message), r.PC)
+	r.Attrs(func(attr slog.Attr) bool {
+		record.AddAttrs(attr)
+		return true
+	})
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *trimHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &trimHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *trimHandler) WithGroup(name string) slog.Handler {
+	return &trimHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// logWriter turns standard log package lines into records. Those lines carry no level, so
+// they are all info: warnings and errors are logged with slog at their own level.
+type logWriter struct {
+	handler slog.Handler
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, strings.TrimSpace(string(p)), 0)
+	if err := w.handler.Handle(context.Background(), record); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// fatal logs message and err at error level, then exits with status 1
+func fatal(message string, err error) {
+	slog.Error(fmt.Sprintf("%s: %v", message, err), "error", err)
+	os.Exit(1)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"log/slog"
+	"testing"
+)
+
+func TestJSONLogLevels(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+	defer log.SetOutput(log.Writer())
+	defer log.SetFlags(log.Flags())
+
+	tests := []struct {
+		name      string
+		log       func()
+		wantLevel string
+	}{
+		{"standard log line", func() { log.Println("📄 Generating findings reports...") }, "INFO"},
+		{"standard log line with a warning emoji", func() { log.Println("⚠️  not a warning") }, "INFO"},
+		{"slog warning", func() { slog.Warn("⚠️  Could not save verification cache", "error", "disk full") }, "WARN"},
+		{"slog error", func() { slog.Error("❌ Failed to send email notification", "error", "timeout") }, "ERROR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := setLogFormat(logFormatJSON, &out); err != nil {
+				t.Fatal(err)
+			}
+			tt.log()
+
+			var record map[string]any
+			if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+				t.Fatalf("not a JSON record: %q", out.String())
+			}
+			if record["level"] != tt.wantLevel {
+				t.Errorf("level = %v, want %s", record["level"], tt.wantLevel)
+			}
+		})
+	}
+}
@@ -1,13 +1,14 @@
 package main
 
 import (
+	"context"
 	"flag"
-	"fmt"
 	"io"
 	"log"
 	"os"
-	"path/filepath"
-	"time"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/yourusername/postman-observer/config"
 	"github.com/yourusername/postman-observer/observer"
@@ -19,8 +20,18 @@ func main() {
 	envFile := flag.String("env", ".env", "Path to .env file (optional)")
 	useEnv := flag.Bool("use-env", false, "Use environment variables instead of config file")
 	once := flag.Bool("once", false, "Run once and exit (for testing or cron jobs)")
-	dryRun := flag.Bool("dry-run", false, "Search and scan only, don't send emails")
+	dryRun := flag.Bool("dry-run", false, "Search and scan only: write reports (named dryrun_...) but send no email or Discord alerts")
 	logDir := flag.String("log-dir", "", "Directory to store log files")
+	logFormat := flag.String("log-format", "", "Log format: text (default) or json")
+	logMaxSize := flag.Int("log-max-size", 0, "Rotate the log file after this many MB (default 100; -1 never rotates)")
+	logMaxFiles := flag.Int("log-max-files", 0, "Log files kept in the log directory (default 10; -1 keeps all)")
+	noRateLimit := flag.Bool("no-rate-limit", false, "Disable Postman API rate limiting (useful with -once)")
+	noVerifyCache := flag.Bool("no-verify-cache", false, "Re-check every secret instead of reusing cached verification results")
+	verifyReport := flag.String("verify-report", "", "Re-verify the secrets in a JSON report and write an updated report, then exit")
+	collectionID := flag.String("collection", "", "Scan a single collection by ID (skips the keyword search), write reports, then exit")
+	envScan := flag.String("env-scan", "", "Scan a Postman environment (exported JSON file or environment ID), write reports, then exit")
+	formats := flag.String("formats", "", "Comma-separated reports to write, e.g. json,sarif (json, html, markdown, csv, sarif, delta; default all)")
+	scanWorkspaces := flag.Bool("workspaces", false, "Scan the environments of every workspace the API key can access, write reports, then exit")
 	flag.Parse()
 
 	// Load .env file if it exists (before setting up logging)
@@ -34,8 +45,22 @@ func main() {
 		logDirectory = config.GetEnv("LOG_DIR", "logs")
 	}
 
+	logFormatName := *logFormat
+	if logFormatName == "" {
+		logFormatName = config.GetEnv("LOG_FORMAT", logFormatText)
+	}
+
+	logMaxSizeMB := *logMaxSize
+	if logMaxSizeMB == 0 {
+		logMaxSizeMB = config.GetEnvInt("LOG_MAX_SIZE_MB", defaultLogMaxSizeMB)
+	}
+	logMaxFileCount := *logMaxFiles
+	if logMaxFileCount == 0 {
+		logMaxFileCount = config.GetEnvInt("LOG_MAX_FILES", defaultLogMaxFiles)
+	}
+
 	// Setup logging to both file and console
-	if err := setupLogging(logDirectory); err != nil {
+	if err := setupLogging(logDirectory, logFormatName, logMaxSizeMB, logMaxFileCount); err != nil {
 		log.Fatalf("❌ Failed to setup logging: %v", err)
 	}
 
@@ -47,64 +72,110 @@ func main() {
 		log.Println("📝 Loading configuration from environment variables")
 		cfg, err = config.LoadConfigFromEnv()
 		if err != nil {
-			log.Fatalf("❌ Failed to load configuration from environment: %v", err)
+			fatal("❌ Failed to load configuration from environment", err)
 		}
 	} else {
 		log.Printf("📝 Loading configuration from: %s", *configPath)
 		cfg, err = config.LoadConfig(*configPath)
 		if err != nil {
-			log.Fatalf("❌ Failed to load configuration: %v", err)
+			fatal("❌ Failed to load configuration", err)
+		}
+	}
+
+	if *noRateLimit {
+		log.Println("⚡ Postman API rate limiting disabled")
+		cfg.Postman.DisableRateLimit = true
+	}
+
+	if *noVerifyCache {
+		cfg.DeepScan.DisableVerifyCache = true
+	}
+
+	if *formats != "" {
+		if cfg.Reports.Formats, err = config.ParseReportFormats(strings.Split(*formats, ",")); err != nil {
+			fatal("❌ Invalid -formats", err)
 		}
+		log.Printf("📄 Writing %s reports only", strings.Join(cfg.Reports.Formats, ", "))
 	}
 
 	// Create and start monitor
 	mon := observer.NewMonitor(cfg)
+	defer mon.Close()
 
 	// Set dry-run mode if requested
 	if *dryRun {
-		log.Println("🧪 Running in DRY-RUN mode (no emails will be sent)")
+		log.Println("🧪 Running in DRY-RUN mode (reports are written, no alerts are sent)")
 		mon.SetDryRun(true)
 	}
 
+	// Cancel on SIGINT/SIGTERM so an in-progress check can drain and exit cleanly
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *verifyReport != "" {
+		reportPath, err := mon.VerifyReport(ctx, *verifyReport)
+		if err != nil {
+			fatal("❌ Re-verification failed", err)
+		}
+		log.Printf("✅ Updated report written to: %s", reportPath)
+		return
+	}
+
+	if *collectionID != "" {
+		if err := mon.ScanCollectionByID(ctx, *collectionID); err != nil {
+			fatal("❌ Collection scan failed", err)
+		}
+		log.Println("✅ Collection scan completed")
+		return
+	}
+
+	if *envScan != "" {
+		if err := mon.ScanEnvironment(ctx, *envScan); err != nil {
+			fatal("❌ Environment scan failed", err)
+		}
+		log.Println("✅ Environment scan completed")
+		return
+	}
+
+	if *scanWorkspaces {
+		if err := mon.ScanWorkspaces(ctx); err != nil {
+			fatal("❌ Workspace scan failed", err)
+		}
+		log.Println("✅ Workspace scan completed")
+		return
+	}
+
 	if *once {
 		log.Println("Running in single-check mode")
-		if err := mon.RunOnce(); err != nil {
-			log.Fatalf("❌ Check failed: %v", err)
+		if err := mon.RunOnce(ctx); err != nil {
+			fatal("❌ Check failed", err)
 		}
 		log.Println("✅ Single check completed successfully")
-		os.Exit(0)
+		return
 	}
 
 	// Run in continuous monitoring mode
-	mon.Start()
+	mon.Start(ctx)
 }
 
-// setupLogging configures logging to both file and console
-func setupLogging(logDir string) error {
-	// Create logs directory if it doesn't exist
-	if err := os.MkdirAll(logDir, 0755); err != nil {
-		return fmt.Errorf("failed to create log directory: %w", err)
-	}
-
-	// Create log file with timestamp (includes time with AM/PM)
-	timestamp := time.Now().Format("2006-01-02_03-04-05PM")
-	logFile := filepath.Join(logDir, fmt.Sprintf("observer_%s.log", timestamp))
-
-	// Open log file (create new file for each run)
-	file, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+// setupLogging configures logging to both file and console in the given format. The file
+// is rotated once it reaches maxSizeMB, keeping maxFiles log files (-1 disables either limit).
+func setupLogging(logDir, format string, maxSizeMB, maxFiles int) error {
+	// Open a new log file for this run; only the file sink rotates, not the console
+	file, err := openRotatingFile(logDir, int64(maxSizeMB)<<20, maxFiles)
 	if err != nil {
-		return fmt.Errorf("failed to open log file: %w", err)
+		return err
 	}
 
 	// Setup multi-writer (console + file)
 	multiWriter := io.MultiWriter(os.Stdout, file)
-	log.SetOutput(multiWriter)
-
-	// Set log format with date and time
-	log.SetFlags(log.Ldate | log.Ltime)
+	if err := setLogFormat(format, multiWriter); err != nil {
+		file.Close()
+		return err
+	}
 
 	log.Printf("════════════════════════════════════════════════════════════")
-	log.Printf("🔍 Postman Observer - Logging to: %s", logFile)
+	log.Printf("🔍 Postman Observer - Logging to: %s", file.Name())
 	log.Printf("════════════════════════════════════════════════════════════")
 
 	return nil
@@ -0,0 +1,187 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/yourusername/postman-observer/config"
+	"github.com/yourusername/postman-observer/scanner"
+)
+
+// Discord embed limits (https://discord.com/developers/docs/resources/message#embed-object-embed-limits)
+const (
+	discordMaxEmbeds      = 10
+	discordMaxTitle       = 256
+	discordMaxDescription = 4096
+	discordMaxFieldValue  = 1024
+	discordMaxTotalChars  = 6000
+
+	discordColorCritical = 0xE74C3C // Red
+	discordColorWarning  = 0xF39C12 // Orange
+)
+
+// DiscordNotifier handles Discord webhook notifications
+type DiscordNotifier struct {
+	config     config.DiscordConfig
+	httpClient *http.Client
+	redaction  scanner.RedactionPolicy
+}
+
+type discordMessage struct {
+	Content string         `json:"content,omitempty"`
+	Embeds  []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	URL         string         `json:"url,omitempty"`
+	Color       int            `json:"color"`
+	Fields      []discordField `json:"fields"`
+	Timestamp   string         `json:"timestamp,omitempty"`
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+// NewDiscordNotifier creates a new Discord notifier
+func NewDiscordNotifier(cfg config.DiscordConfig) *DiscordNotifier {
+	return &DiscordNotifier{
+		config: cfg,
+		httpClient: &http.Client{
+			Timeout: 15 * time.Second,
+		},
+		redaction: scanner.RedactPartial,
+	}
+}
+
+// SetRedactionPolicy chooses how Discord messages show secret values (partially redacted
+// by default)
+func (n *DiscordNotifier) SetRedactionPolicy(policy scanner.RedactionPolicy) {
+	n.redaction = policy
+}
+
+// SendAlert posts one embed per alert to the configured Discord webhook,
+// splitting into several messages to stay within Discord's embed limits
+func (n *DiscordNotifier) SendAlert(alerts []Alert) error {
+	if len(alerts) == 0 || n.config.WebhookURL == "" {
+		return nil
+	}
+
+	var batch []discordEmbed
+	batchChars := 0
+
+	for _, alert := range alerts {
+		embed := n.buildEmbed(alert)
+		size := embedSize(embed)
+
+		if len(batch) == discordMaxEmbeds || (len(batch) > 0 && batchChars+size > discordMaxTotalChars) {
+			if err := n.post(batch); err != nil {
+				return err
+			}
+			batch = nil
+			batchChars = 0
+		}
+
+		batch = append(batch, embed)
+		batchChars += size
+	}
+
+	return n.post(batch)
+}
+
+// buildEmbed creates the embed for a single alert, red for secrets and orange for warnings
+func (n *DiscordNotifier) buildEmbed(alert Alert) discordEmbed {
+	title := "⚠️ Public Collection Found: " + alert.Collection.Name
+	color := discordColorWarning
+	description := "Collection is publicly accessible on the Postman Public Network."
+	if len(alert.Secrets) > 0 {
+		title = "🚨 Public Collection with Secrets: " + alert.Collection.Name
+		color = discordColorCritical
+
+		verifiedCount := 0
+		for _, secret := range alert.Secrets {
+			if secret.Verification != nil && secret.Verification.IsValid {
+				verifiedCount++
+			}
+		}
+
+		var buf bytes.Buffer
+		if verifiedCount > 0 {
+			buf.WriteString(fmt.Sprintf("**%d ACTIVE secret(s) verified!**\n", verifiedCount))
+		}
+		for _, secret := range alert.Secrets {
+			buf.WriteString(fmt.Sprintf("• %s: `%s`\n", secret.Type, n.redaction.Redact(secret)))
+		}
+		description = buf.String()
+	}
+
+	owner := alert.Collection.Owner
+	if owner == "" {
+		owner = "Unknown"
+	}
+
+	return discordEmbed{
+		Title:       truncate(title, discordMaxTitle),
+		Description: truncate(description, discordMaxDescription),
+		URL:         fmt.Sprintf("https://www.postman.com/collection/%s", alert.Collection.ID),
+		Color:       color,
+		Fields: []discordField{
+			{Name: "Collection", Value: truncate(alert.Collection.Name, discordMaxFieldValue), Inline: true},
+			{Name: "Keyword", Value: truncate(alert.Keyword, discordMaxFieldValue), Inline: true},
+			{Name: "Owner", Value: truncate(owner, discordMaxFieldValue), Inline: true},
+			{Name: "Secrets", Value: fmt.Sprintf("%d", len(alert.Secrets)), Inline: true},
+		},
+		Timestamp: alert.Timestamp.Format(time.RFC3339),
+	}
+}
+
+// post sends a batch of embeds to the webhook
+func (n *DiscordNotifier) post(embeds []discordEmbed) error {
+	if len(embeds) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(discordMessage{Embeds: embeds})
+	if err != nil {
+		return fmt.Errorf("failed to marshal Discord message: %w", err)
+	}
+
+	resp, err := n.httpClient.Post(n.config.WebhookURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to send Discord notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("discord webhook returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// embedSize counts the characters Discord includes in its 6000 character total
+func embedSize(embed discordEmbed) int {
+	size := len([]rune(embed.Title)) + len([]rune(embed.Description))
+	for _, field := range embed.Fields {
+		size += len([]rune(field.Name)) + len([]rune(field.Value))
+	}
+	return size
+}
+
+// truncate shortens s to at most limit characters, marking the cut with an ellipsis
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "…"
+}
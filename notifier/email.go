@@ -2,9 +2,23 @@ package notifier
 
 import (
 	"bytes"
+	"crypto/tls"
+	"encoding/base64"
 	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
 	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/yourusername/postman-observer/config"
@@ -12,9 +26,24 @@ import (
 	"github.com/yourusername/postman-observer/scanner"
 )
 
-// EmailNotifier handles email notifications
+// EmailNotifier handles email notifications. With a minimum interval configured it
+// works as a digest: at most one email is sent per window, and alerts arriving in
+// between are queued and merged by collection ID.
 type EmailNotifier struct {
-	config config.EmailConfig
+	config    config.EmailConfig
+	redaction scanner.RedactionPolicy
+
+	mu             sync.Mutex
+	pending        []Alert     // Alerts waiting for the next digest email
+	pendingReports []string    // Report files to attach to the next digest email
+	lastSent       time.Time   // When the last email went out
+	timer          *time.Timer // Fires the next digest email, nil if none is scheduled
+}
+
+// attachment is a report file attached to an alert email
+type attachment struct {
+	name string
+	data []byte
 }
 
 // Alert represents a security alert
@@ -29,16 +58,104 @@ type Alert struct {
 // NewEmailNotifier creates a new email notifier
 func NewEmailNotifier(cfg config.EmailConfig) *EmailNotifier {
 	return &EmailNotifier{
-		config: cfg,
+		config:    cfg,
+		redaction: scanner.RedactPartial,
 	}
 }
 
-// SendAlert sends an email alert for a discovered sensitive collection
-func (n *EmailNotifier) SendAlert(alerts []Alert) error {
+// SetRedactionPolicy chooses how emails show secret values (partially redacted by default)
+func (n *EmailNotifier) SetRedactionPolicy(policy scanner.RedactionPolicy) {
+	n.redaction = policy
+}
+
+// SendAlert sends an email alert for a discovered sensitive collection, or queues it
+// for the next digest if an email already went out within email.min_interval_minutes.
+// With email.attach_reports set, the given report files are attached.
+func (n *EmailNotifier) SendAlert(alerts []Alert, reports []string) error {
 	if len(alerts) == 0 {
 		return nil
 	}
 
+	interval := time.Duration(n.config.MinIntervalMinutes) * time.Minute
+	if interval <= 0 {
+		return n.send(alerts, reports)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.pending = mergeAlerts(n.pending, alerts)
+	n.pendingReports = append(n.pendingReports, reports...)
+
+	if n.timer != nil {
+		log.Printf("📬 Email digest: %d alert(s) queued for the scheduled email", len(n.pending))
+		return nil
+	}
+
+	wait := time.Until(n.lastSent.Add(interval))
+	if wait <= 0 {
+		return n.flushLocked()
+	}
+
+	log.Printf("📬 Email digest: %d alert(s) queued, next email in %s", len(n.pending), wait.Round(time.Second))
+	n.timer = time.AfterFunc(wait, func() {
+		if err := n.Flush(); err != nil {
+			slog.Error(fmt.Sprintf("❌ Failed to send email digest: %v", err), "error", err)
+		}
+	})
+
+	return nil
+}
+
+// Flush immediately sends any alerts queued for the digest
+func (n *EmailNotifier) Flush() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.flushLocked()
+}
+
+// flushLocked sends the queued alerts; n.mu must be held. Alerts stay queued if sending fails.
+func (n *EmailNotifier) flushLocked() error {
+	if n.timer != nil {
+		n.timer.Stop()
+		n.timer = nil
+	}
+	if len(n.pending) == 0 {
+		return nil
+	}
+
+	if err := n.send(n.pending, n.pendingReports); err != nil {
+		return err
+	}
+
+	n.pending = nil
+	n.pendingReports = nil
+	n.lastSent = time.Now()
+	return nil
+}
+
+// mergeAlerts adds alerts to queued, replacing any queued alert for the same collection
+func mergeAlerts(queued, alerts []Alert) []Alert {
+	index := make(map[string]int, len(queued))
+	for i, alert := range queued {
+		index[alert.Collection.ID] = i
+	}
+
+	for _, alert := range alerts {
+		if i, ok := index[alert.Collection.ID]; ok {
+			queued[i] = alert // Newer scan of the same collection
+			continue
+		}
+		index[alert.Collection.ID] = len(queued)
+		queued = append(queued, alert)
+	}
+
+	return queued
+}
+
+// send emails a batch of alerts right away
+func (n *EmailNotifier) send(alerts []Alert, reports []string) error {
 	// Count critical alerts (with secrets) vs warnings (public only)
 	criticalCount := 0
 	for _, alert := range alerts {
@@ -54,9 +171,83 @@ func (n *EmailNotifier) SendAlert(alerts []Alert) error {
 		subject = fmt.Sprintf("⚠️  WARNING: %d Public Collection(s) Found", len(alerts))
 	}
 
-	body := n.buildEmailBody(alerts)
+	textBody := n.buildTextBody(alerts)
+	htmlBody := n.buildEmailBody(alerts)
 
-	return n.sendEmail(subject, body)
+	var attachments []attachment
+	if n.config.AttachReports {
+		attachments = n.loadAttachments(reports)
+	}
+
+	return n.sendEmail(subject, textBody, htmlBody, attachments)
+}
+
+// loadAttachments reads the report files to attach, skipping any that would take the
+// email past email.max_attachment_kb once base64-encoded
+func (n *EmailNotifier) loadAttachments(paths []string) []attachment {
+	budget := n.config.MaxAttachmentKB * 1024
+	var attachments []attachment
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			slog.Warn(fmt.Sprintf("⚠️  Could not attach report %s: %v", path, err), "report", path, "error", err)
+			continue
+		}
+		size := encodedSize(len(data))
+		if size > budget {
+			slog.Warn(fmt.Sprintf("⚠️  Not attaching report %s (%d KB encoded): over the %d KB attachment limit", path, size/1024, n.config.MaxAttachmentKB),
+				"report", path, "size_kb", size/1024, "limit_kb", n.config.MaxAttachmentKB)
+			continue
+		}
+		budget -= size
+		attachments = append(attachments, attachment{name: filepath.Base(path), data: data})
+	}
+
+	return attachments
+}
+
+// encodedSize is how many bytes n bytes of attachment take in the message: base64 (4
+// bytes per 3) in lines of 76 characters ending in CRLF, as writeBase64Lines writes them
+func encodedSize(n int) int {
+	encoded := base64.StdEncoding.EncodedLen(n)
+	lines := (encoded + 75) / 76
+	return encoded + 2*lines
+}
+
+// buildTextBody creates the plaintext alternative to the HTML email body
+func (n *EmailNotifier) buildTextBody(alerts []Alert) string {
+	var buf bytes.Buffer
+
+	buf.WriteString("Postman Observer Security Alert\r\n")
+	buf.WriteString("Sensitive collections detected on Postman Public Network\r\n\r\n")
+	buf.WriteString(fmt.Sprintf("Alert Summary: %d sensitive collection(s) found at %s\r\n",
+		len(alerts), time.Now().Format("2006-01-02 15:04:05 MST")))
+
+	for i, alert := range alerts {
+		alertType := "PUBLIC COLLECTION FOUND"
+		if len(alert.Secrets) > 0 {
+			alertType = "CRITICAL: PUBLIC COLLECTION WITH SECRETS"
+		}
+
+		verifiedCount := 0
+		for _, secret := range alert.Secrets {
+			if secret.Verification != nil && secret.Verification.IsValid {
+				verifiedCount++
+			}
+		}
+
+		buf.WriteString(fmt.Sprintf("\r\n%d. %s [%s]\r\n", i+1, alert.Collection.Name, alertType))
+		buf.WriteString(fmt.Sprintf("   Collection ID:   %s\r\n", alert.Collection.ID))
+		buf.WriteString(fmt.Sprintf("   Matched Keyword: %s\r\n", alert.Keyword))
+		buf.WriteString(fmt.Sprintf("   Secrets Found:   %d (%d verified active)\r\n", len(alert.Secrets), verifiedCount))
+		buf.WriteString(fmt.Sprintf("   Detected at:     %s\r\n", alert.Timestamp.Format("2006-01-02 15:04:05 MST")))
+	}
+
+	buf.WriteString("\r\nThis is an automated alert from Postman Observer.\r\n")
+	buf.WriteString("Please review these collections and take appropriate action if they contain sensitive information.\r\n")
+
+	return buf.String()
 }
 
 // buildEmailBody creates the HTML email body
@@ -139,20 +330,33 @@ body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
 				verificationStatus := ""
 				if secret.Verification != nil {
 					statusColor := "#7f8c8d"
+					message := secret.Verification.Message
 					if secret.Verification.IsValid {
 						statusColor = "#c0392b"
-					} else if secret.Verification.RateLimited {
+					} else if secret.Verification.Inconclusive {
+						// The secret may be live; don't let it read like a dead one
+						statusColor = "#f39c12"
+						message = fmt.Sprintf("⚠️ Could not verify (%s)", strings.ReplaceAll(string(secret.Verification.Status), "_", " "))
+						if secret.Verification.Note != "" {
+							message += " - " + secret.Verification.Note
+						}
+					} else if secret.Verification.Status == scanner.StatusUnsupported || secret.Verification.Status == scanner.StatusSkipped || secret.Verification.Status == scanner.StatusReachable || secret.Verification.Status == scanner.StatusInactive {
 						statusColor = "#f39c12"
 					}
 					verificationStatus = fmt.Sprintf(`<br/><small style="color: %s; font-weight: bold;">%s</small>`,
-						statusColor, escapeHTML(secret.Verification.Message))
+						statusColor, escapeHTML(message))
+					verificationStatus += scopeBadges(secret.Verification.Details)
+				}
+				if snippet := n.redaction.Snippet(secret); snippet != "" {
+					verificationStatus += fmt.Sprintf(`<pre style="background-color: #f4f4f4; padding: 5px; margin: 4px 0; font-size: 0.85em; white-space: pre-wrap; word-break: break-all;">%s</pre>`,
+						escapeHTML(snippet))
 				}
 
 				buf.WriteString(fmt.Sprintf(`
 <li><strong>%s:</strong> <code style="background-color: #ffe6e6; padding: 2px 5px; border-radius: 3px;">%s</code><br/>
 <small style="color: #7f8c8d;">Location: %s</small>%s</li>`,
 					escapeHTML(secret.Type),
-					escapeHTML(secret.Value),
+					escapeHTML(n.redaction.Redact(secret)),
 					escapeHTML(secret.Location),
 					verificationStatus,
 				))
@@ -179,43 +383,213 @@ body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
 	return buf.String()
 }
 
-// sendEmail sends an email using SMTP
-func (n *EmailNotifier) sendEmail(subject, body string) error {
-	auth := smtp.PlainAuth("", n.config.From, n.config.Password, n.config.SMTPHost)
+// smtpDialTimeout bounds how long connecting to the SMTP server may take
+const smtpDialTimeout = 30 * time.Second
 
+// sendEmail sends an email using SMTP. Port 465 uses implicit TLS; any other port
+// upgrades with STARTTLS, which is mandatory on the 587 submission port. With a password
+// configured, the server must offer AUTH.
+func (n *EmailNotifier) sendEmail(subject, textBody, htmlBody string, attachments []attachment) error {
 	// Build email message
-	msg := n.buildMessage(subject, body)
+	msg, err := n.buildMessage(subject, textBody, htmlBody, attachments)
+	if err != nil {
+		return fmt.Errorf("failed to build email: %w", err)
+	}
+
+	addr := net.JoinHostPort(n.config.SMTPHost, strconv.Itoa(n.config.SMTPPort))
+	tlsConfig := &tls.Config{
+		ServerName:         n.config.SMTPHost,
+		InsecureSkipVerify: n.config.InsecureSkipVerify,
+	}
 
-	addr := fmt.Sprintf("%s:%d", n.config.SMTPHost, n.config.SMTPPort)
+	dialer := &net.Dialer{Timeout: smtpDialTimeout}
 
-	err := smtp.SendMail(
-		addr,
-		auth,
-		n.config.From,
-		n.config.To,
-		[]byte(msg),
-	)
+	var conn net.Conn
+	if n.config.SMTPPort == 465 {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
 
+	client, err := smtp.NewClient(conn, n.config.SMTPHost)
 	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if n.config.SMTPPort != 465 {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("failed to start TLS: %w", err)
+			}
+		} else if n.config.SMTPPort == 587 {
+			return fmt.Errorf("SMTP server %s does not support STARTTLS", addr)
+		}
+	}
+
+	if n.config.Password != "" {
+		// Sending unauthenticated would drop the configured credentials without a word
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return fmt.Errorf("SMTP server %s does not support authentication", addr)
+		}
+		auth := smtp.PlainAuth("", n.config.From, n.config.Password, n.config.SMTPHost)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	if err := client.Mail(n.config.From); err != nil {
 		return fmt.Errorf("failed to send email: %w", err)
 	}
+	for _, to := range n.config.To {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("failed to add recipient %s: %w", to, err)
+		}
+	}
 
-	return nil
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if _, err := w.Write([]byte(msg)); err != nil {
+		w.Close()
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return client.Quit()
 }
 
-// buildMessage constructs the email message
-func (n *EmailNotifier) buildMessage(subject, body string) string {
+// buildMessage constructs a multipart/alternative email with plaintext and HTML parts,
+// wrapped in multipart/mixed when there are attachments
+func (n *EmailNotifier) buildMessage(subject, textBody, htmlBody string, attachments []attachment) (string, error) {
 	var msg bytes.Buffer
 
 	msg.WriteString(fmt.Sprintf("From: %s\r\n", n.config.From))
 	msg.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(n.config.To, ",")))
-	msg.WriteString(fmt.Sprintf("Subject: %s\r\n", subject))
+	msg.WriteString(fmt.Sprintf("Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", subject)))
 	msg.WriteString("MIME-Version: 1.0\r\n")
-	msg.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
-	msg.WriteString("\r\n")
-	msg.WriteString(body)
 
-	return msg.String()
+	if len(attachments) == 0 {
+		alternative := multipart.NewWriter(&msg)
+		msg.WriteString(fmt.Sprintf("Content-Type: multipart/alternative; boundary=%q\r\n\r\n", alternative.Boundary()))
+		if err := writeAlternative(alternative, textBody, htmlBody); err != nil {
+			return "", err
+		}
+		return msg.String(), nil
+	}
+
+	mixed := multipart.NewWriter(&msg)
+	msg.WriteString(fmt.Sprintf("Content-Type: multipart/mixed; boundary=%q\r\n\r\n", mixed.Boundary()))
+
+	var bodies bytes.Buffer
+	alternative := multipart.NewWriter(&bodies)
+	if err := writeAlternative(alternative, textBody, htmlBody); err != nil {
+		return "", err
+	}
+	body, err := mixed.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": alternative.Boundary()})},
+	})
+	if err != nil {
+		return "", err
+	}
+	if _, err := body.Write(bodies.Bytes()); err != nil {
+		return "", err
+	}
+
+	for _, file := range attachments {
+		contentType := mime.TypeByExtension(filepath.Ext(file.name))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		w, err := mixed.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": file.name})},
+		})
+		if err != nil {
+			return "", err
+		}
+		if err := writeBase64Lines(w, file.data); err != nil {
+			return "", err
+		}
+	}
+
+	if err := mixed.Close(); err != nil {
+		return "", err
+	}
+
+	return msg.String(), nil
+}
+
+// writeAlternative writes the plaintext and HTML parts of a multipart/alternative body
+func writeAlternative(parts *multipart.Writer, textBody, htmlBody string) error {
+	// Least preferred first: clients render the last part they understand
+	for _, part := range []struct {
+		contentType string
+		body        string
+	}{
+		{"text/plain; charset=UTF-8", textBody},
+		{"text/html; charset=UTF-8", htmlBody},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return err
+		}
+
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return err
+		}
+		if err := qp.Close(); err != nil {
+			return err
+		}
+	}
+
+	return parts.Close()
+}
+
+// writeBase64Lines writes data base64-encoded in 76-character lines, as MIME requires
+func writeBase64Lines(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 0 {
+		line := encoded[:min(76, len(encoded))]
+		if _, err := io.WriteString(w, line+"\r\n"); err != nil {
+			return err
+		}
+		encoded = encoded[len(line):]
+	}
+	return nil
+}
+
+// scopeBadges renders a verified token's scopes as inline-styled badges, with the scopes
+// that grant write or admin access in red
+func scopeBadges(details map[string]string) string {
+	scopes := scanner.ScopeBadges(details)
+	if len(scopes) == 0 {
+		return ""
+	}
+
+	var badges strings.Builder
+	badges.WriteString("<br/>")
+	for _, scope := range scopes {
+		color := "#7f8c8d"
+		if scope.Dangerous {
+			color = "#c0392b"
+		}
+		badges.WriteString(fmt.Sprintf(`<span style="display: inline-block; background-color: %s; color: #ffffff; padding: 1px 6px; margin: 2px 3px 0 0; border-radius: 3px; font-size: 0.8em;">%s</span>`,
+			color, escapeHTML(scope.Scope)))
+	}
+	return badges.String()
 }
 
 // escapeHTML escapes HTML special characters
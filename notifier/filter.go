@@ -0,0 +1,30 @@
+package notifier
+
+import "github.com/yourusername/postman-observer/config"
+
+// FilterAlerts returns the alerts that notify under monitoring.alert_on: every alert, the
+// ones with secrets, or the ones with at least one secret verified active
+func FilterAlerts(alerts []Alert, alertOn string) []Alert {
+	if alertOn == "" || alertOn == config.AlertOnAll {
+		return alerts
+	}
+
+	var filtered []Alert
+	for _, alert := range alerts {
+		if alertOn == config.AlertOnSecrets && len(alert.Secrets) > 0 ||
+			alertOn == config.AlertOnVerifiedActive && hasActiveSecret(alert) {
+			filtered = append(filtered, alert)
+		}
+	}
+	return filtered
+}
+
+// hasActiveSecret reports whether any of an alert's secrets was verified active
+func hasActiveSecret(alert Alert) bool {
+	for _, secret := range alert.Secrets {
+		if secret.Verification != nil && secret.Verification.IsValid {
+			return true
+		}
+	}
+	return false
+}
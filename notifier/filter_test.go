@@ -0,0 +1,46 @@
+package notifier
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/postman-observer/config"
+	"github.com/yourusername/postman-observer/postman"
+	"github.com/yourusername/postman-observer/scanner"
+)
+
+func TestFilterAlerts(t *testing.T) {
+	active := &scanner.VerificationResult{Status: scanner.StatusActive, IsValid: true}
+	invalid := &scanner.VerificationResult{Status: scanner.StatusInvalid}
+	alert := func(id string, secrets ...scanner.SecretMatch) Alert {
+		return Alert{Keyword: "acme", Collection: postman.Collection{ID: id}, Secrets: secrets}
+	}
+	alerts := []Alert{
+		alert("public-only"),
+		alert("unverified", scanner.SecretMatch{Type: "GitHub Token"}),
+		alert("invalid", scanner.SecretMatch{Type: "GitHub Token", Verification: invalid}),
+		alert("active", scanner.SecretMatch{Type: "GitHub Token", Verification: invalid}, scanner.SecretMatch{Type: "Stripe API Key", Verification: active}),
+	}
+
+	tests := []struct {
+		name    string
+		alertOn string
+		want    []string
+	}{
+		{"unset", "", []string{"public-only", "unverified", "invalid", "active"}},
+		{"all", config.AlertOnAll, []string{"public-only", "unverified", "invalid", "active"}},
+		{"secrets", config.AlertOnSecrets, []string{"unverified", "invalid", "active"}},
+		{"verified active", config.AlertOnVerifiedActive, []string{"active"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, alert := range FilterAlerts(alerts, tt.alertOn) {
+				got = append(got, alert.Collection.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterAlerts(%q) = %v, want %v", tt.alertOn, got, tt.want)
+			}
+		})
+	}
+}
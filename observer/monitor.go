@@ -1,16 +1,26 @@
 package observer
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/yourusername/postman-observer/config"
+	"github.com/yourusername/postman-observer/metrics"
 	"github.com/yourusername/postman-observer/notifier"
 	"github.com/yourusername/postman-observer/postman"
 	"github.com/yourusername/postman-observer/reporter"
 	"github.com/yourusername/postman-observer/scanner"
+	"github.com/yourusername/postman-observer/schedule"
 )
 
 // Monitor orchestrates the monitoring process
@@ -19,42 +29,175 @@ type Monitor struct {
 	client         *postman.Client
 	webScraper     *postman.WebScraper
 	notifier       *notifier.EmailNotifier
+	discord        *notifier.DiscordNotifier
 	reporter       *reporter.Reporter
 	secretScanner  *scanner.SecretScanner
 	secretVerifier *scanner.SecretVerifier
+	auditLog       *scanner.AuditLog
 	seenAlerts     map[string]time.Time // Track already alerted collections
 	dryRun         bool                 // If true, don't send emails
 	currentUserID  string               // Current user's ID to filter own collections
+	runMu          sync.Mutex           // Guards cancel, done and stopped between Start and Stop
+	cancel         context.CancelFunc   // Cancels the running monitoring loop
+	done           chan struct{}        // Closed when the monitoring loop has exited
+	stopped        bool                 // Stop was called; a later Start returns at once
+
+	schedule            *schedule.Schedule // Cron schedule replacing the fixed interval, if configured
+	lastSuccessfulCheck atomic.Int64       // Unix time the last check completed without error, for /readyz
+	startedAt           atomic.Int64       // Unix time Start began, for /readyz before a scheduled first check
 }
 
 // NewMonitor creates a new monitor instance
 func NewMonitor(cfg *config.Config) *Monitor {
+	secretVerifier := scanner.NewSecretVerifier()
+	secretVerifier.SetConnectivityChecks(cfg.DeepScan.VerifyConnectivity)
+	secretVerifier.SetPyPIUploadCheck(cfg.DeepScan.VerifyPyPIUpload)
+	secretVerifier.SetWorkers(cfg.DeepScan.VerifyWorkers)
+	secretVerifier.SetRetry(cfg.DeepScan.VerifyMaxAttempts, time.Duration(cfg.DeepScan.VerifyRetryDelayMs)*time.Millisecond)
+	secretVerifier.SetTimeout(time.Duration(cfg.Timeouts.Verify) * time.Second)
+	secretVerifier.SetRunBudget(time.Duration(cfg.DeepScan.VerifyBudgetMinutes)*time.Minute, cfg.DeepScan.MaxVerifications)
+	auditLog := scanner.NewAuditLog(cfg.DeepScan.AuditLog)
+	secretVerifier.SetAuditHook(auditLog)
+	if cfg.DeepScan.DryVerify {
+		log.Printf("🧪 Dry-verify mode: secrets are not verified, only audited in %s", cfg.DeepScan.AuditLog)
+		secretVerifier.SetDryVerify(true)
+	}
+	// The custom verifiers were validated with the configuration
+	for secretType, custom := range cfg.DeepScan.CustomVerifiers {
+		customVerifier, _ := custom.Verifier()
+		secretVerifier.SetCustomVerifier(secretType, customVerifier)
+	}
+	verifyPolicy, unknownTypes := scanner.ParseVerifyPolicy(cfg.DeepScan.VerifyTypes)
+	for _, name := range unknownTypes {
+		if _, ok := cfg.DeepScan.CustomVerifiers[name]; ok {
+			continue // A custom verifier's secret type
+		}
+		slog.Warn(fmt.Sprintf("⚠️  deep_scan.verify_types: %q matches no provider or secret type", name), "verify_type", name)
+	}
+	secretVerifier.SetVerifyPolicy(verifyPolicy)
+
+	// The proxy settings were validated with the configuration
+	verifyTransport, _ := cfg.DeepScan.VerifyProxy.Transport()
+	secretVerifier.SetTransport(verifyTransport)
+	if cfg.DeepScan.RequireVerifyProxy {
+		secretVerifier.RequireProxy(cfg.DeepScan.VerifyProxy.Address())
+	}
+	if cfg.DeepScan.VerifyConnectivity && (cfg.DeepScan.VerifyProxy.URL != "" || cfg.DeepScan.RequireVerifyProxy) {
+		slog.Warn("⚠️  deep_scan.verify_connectivity turned off: its TCP dials can't go through the verification proxy")
+		secretVerifier.SetConnectivityChecks(false)
+	}
+	secretVerifier.SetCacheTTL(time.Duration(cfg.DeepScan.VerifyCacheHours) * time.Hour)
+	if cfg.DeepScan.DisableVerifyCache {
+		log.Println("🔁 Verification cache disabled - every secret is re-checked")
+		secretVerifier.SetCacheTTL(0)
+	}
+	if err := secretVerifier.SetCacheFile(filepath.Join(cfg.State.Dir, "verification_cache.json")); err != nil {
+		slog.Warn(fmt.Sprintf("⚠️  Starting with an empty verification cache: %v", err), "error", err)
+	}
+
+	secretScanner := scanner.NewSecretScanner()
+	secretScanner.SetPlaceholderOptions(cfg.DeepScan.PlaceholderWords, cfg.DeepScan.DropPossiblePlaceholders)
+	secretScanner.SetMinEntropy(cfg.DeepScan.MinEntropy)
+	secretScanner.SetMinConfidence(cfg.DeepScan.MinConfidence)
+	secretScanner.SetWorkers(cfg.DeepScan.Workers)
+	secretScanner.SetCollectionJSONScan(cfg.CollectionJSONScanEnabled())
+	secretScanner.SetMaxExampleBody(cfg.DeepScan.MaxExampleBodyKB * 1024)
+
+	// The pattern files were loaded with the configuration
+	for _, file := range cfg.LoadedPatternFiles() {
+		duplicates := secretScanner.AddPatterns(file.Patterns)
+		for _, reason := range file.Skipped {
+			slog.Warn(fmt.Sprintf("⚠️  %s: skipped %s", file.Path, reason), "pattern_file", file.Path)
+		}
+		for _, reason := range duplicates {
+			slog.Warn(fmt.Sprintf("⚠️  %s: skipped %s", file.Path, reason), "pattern_file", file.Path)
+		}
+		log.Printf("📥 Loaded %d pattern(s) from %s", len(file.Patterns)-len(duplicates), file.Path)
+	}
+
+	// The allowlist patterns were validated with the configuration
+	allowlist, _ := scanner.NewAllowlist(cfg.DeepScan.Allowlist.Values, cfg.DeepScan.Allowlist.Patterns)
+	secretScanner.SetAllowlist(allowlist)
+
+	// The redaction policies were validated with the configuration
+	reportRedaction, _ := scanner.ParseRedactionPolicy(cfg.DeepScan.ReportRedaction, scanner.RedactNone)
+	emailRedaction, _ := scanner.ParseRedactionPolicy(cfg.DeepScan.EmailRedaction, scanner.RedactPartial)
+
+	emailNotifier := notifier.NewEmailNotifier(cfg.Email)
+	emailNotifier.SetRedactionPolicy(emailRedaction)
+
+	// Discord alerts are notifications like the email, so they follow email_redaction
+	discordNotifier := notifier.NewDiscordNotifier(cfg.Discord)
+	discordNotifier.SetRedactionPolicy(emailRedaction)
+
+	reportWriter := reporter.NewReporter(cfg.Reports)
+	reportWriter.SetRedactionPolicy(reportRedaction)
+
+	// Postman API and public search traffic use their own proxy settings
+	postmanTransport, _ := cfg.Postman.Proxy.Transport()
+	client := postman.NewClient(cfg.PostmanAPIKey, cfg.Postman)
+	client.SetTransport(postmanTransport)
+	client.SetTimeout(time.Duration(cfg.Timeouts.Postman) * time.Second)
+	webScraper := postman.NewWebScraper(cfg.Monitoring.ScrapeMaxResults)
+	webScraper.SetTransport(postmanTransport)
+	webScraper.SetTimeout(time.Duration(cfg.Timeouts.Scrape) * time.Second)
+
+	// The expression was validated with the configuration
+	var checkSchedule *schedule.Schedule
+	if cfg.Monitoring.Schedule != "" {
+		checkSchedule, _ = schedule.Parse(cfg.Monitoring.Schedule)
+	}
+
 	return &Monitor{
 		config:         cfg,
-		client:         postman.NewClient(cfg.PostmanAPIKey),
-		webScraper:     postman.NewWebScraper(),
-		notifier:       notifier.NewEmailNotifier(cfg.Email),
-		reporter:       reporter.NewReporter("reports"),
-		secretScanner:  scanner.NewSecretScanner(),
-		secretVerifier: scanner.NewSecretVerifier(),
+		client:         client,
+		webScraper:     webScraper,
+		notifier:       emailNotifier,
+		discord:        discordNotifier,
+		reporter:       reportWriter,
+		secretScanner:  secretScanner,
+		secretVerifier: secretVerifier,
+		auditLog:       auditLog,
 		seenAlerts:     make(map[string]time.Time),
 		dryRun:         false,
+		schedule:       checkSchedule,
 	}
 }
 
-// SetDryRun enables or disables dry-run mode
+// SetDryRun enables or disables dry-run mode: checks search and scan as usual and write
+// their reports, marked as dry-run, but send no email or Discord alerts
 func (m *Monitor) SetDryRun(enabled bool) {
 	m.dryRun = enabled
+	m.reporter.SetDryRun(enabled)
 }
 
-// Start begins the monitoring loop
-func (m *Monitor) Start() {
+// Start begins the monitoring loop and blocks until ctx is cancelled or Stop is called.
+// A check in progress is allowed to drain (reports are still written) before returning.
+func (m *Monitor) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	defer close(done)
+	defer cancel()
+
+	m.runMu.Lock()
+	if m.stopped {
+		m.runMu.Unlock()
+		return
+	}
+	m.cancel, m.done = cancel, done
+	m.runMu.Unlock()
+	m.startedAt.Store(time.Now().Unix())
+
 	log.Println("🔍 Postman Observer started")
 
+	for addr, mux := range m.httpHandlers() {
+		go m.serveHTTP(ctx, addr, mux)
+	}
+
 	// Get current user ID to filter own collections
-	userID, err := m.client.GetCurrentUser()
+	userID, err := m.client.GetCurrentUser(ctx)
 	if err != nil {
-		log.Printf("⚠️  Warning: Could not get current user info: %v", err)
+		slog.Warn(fmt.Sprintf("⚠️  Warning: Could not get current user info: %v", err), "error", err)
 		log.Println("   Continuing without user filtering (may include your own collections)")
 	} else {
 		m.currentUserID = userID
@@ -63,36 +206,84 @@ func (m *Monitor) Start() {
 
 	log.Printf("Monitoring %d keywords, ignoring %d patterns",
 		len(m.config.MonitorKeywords), len(m.config.IgnoreKeywords))
-	log.Printf("Checking every %d hours", m.config.Monitoring.IntervalHours)
+	if m.schedule != nil {
+		log.Printf("Checking on schedule %q", m.config.Monitoring.Schedule)
+	} else {
+		log.Printf("Checking every %d hours", m.config.Monitoring.IntervalHours)
+	}
 
-	// Run immediately on start
-	m.runCheck()
+	// Without a schedule run immediately on start; a schedule's first check waits for its slot
+	if m.schedule == nil {
+		m.runCheck(ctx)
+	}
 
 	// Schedule periodic checks
-	ticker := time.NewTicker(time.Duration(m.config.Monitoring.IntervalHours) * time.Hour)
-	defer ticker.Stop()
+	for {
+		next := m.nextCheck(time.Now())
+		if m.schedule != nil {
+			log.Printf("⏭️  Next check at %s", next.Format("2006-01-02 15:04:05"))
+		}
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			m.flushEmailDigest()
+			log.Println("🛑 Postman Observer stopped")
+			return
+		case <-timer.C:
+			m.runCheck(ctx)
+		}
+	}
+}
 
-	for range ticker.C {
-		m.runCheck()
+// nextCheck returns when the check after one at t is due: the next cron match when a
+// schedule is configured, otherwise IntervalHours later
+func (m *Monitor) nextCheck(t time.Time) time.Time {
+	if m.schedule != nil {
+		if next := m.schedule.Next(t); !next.IsZero() {
+			return next
+		}
 	}
+	return t.Add(time.Duration(m.config.Monitoring.IntervalHours) * time.Hour)
+}
+
+// Stop cancels the monitoring loop and waits for the current check to finish. Called
+// before Start, it makes Start return at once.
+func (m *Monitor) Stop() {
+	m.runMu.Lock()
+	m.stopped = true
+	cancel, done := m.cancel, m.done
+	m.runMu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// Close releases the monitor's files; call it once the monitor is no longer used
+func (m *Monitor) Close() error {
+	return m.auditLog.Close()
 }
 
 // RunOnce runs a single check and exits
-func (m *Monitor) RunOnce() error {
+func (m *Monitor) RunOnce(ctx context.Context) error {
 	// Check if API key is provided
 	if m.config.PostmanAPIKey == "" {
 		log.Println("ℹ️  Running in PUBLIC SCAN mode (no API key provided)")
 		log.Println("   📋 Will scan public collections found via web search")
-		log.Println("   ⚠️  Cannot filter your own collections (no user ID)")
-		log.Println("   ⚠️  Some collections may fail to download if they require authentication")
+		slog.Warn("   ⚠️  Cannot filter your own collections (no user ID)")
+		slog.Warn("   ⚠️  Some collections may fail to download if they require authentication")
 		log.Println("")
 	}
 
 	// Get current user ID to filter own collections
-	userID, err := m.client.GetCurrentUser()
+	userID, err := m.client.GetCurrentUser(ctx)
 	if err != nil {
 		if m.config.PostmanAPIKey != "" {
-			log.Printf("⚠️  Warning: Could not get current user info: %v", err)
+			slog.Warn(fmt.Sprintf("⚠️  Warning: Could not get current user info: %v", err), "error", err)
 		}
 		log.Println("   Continuing without user filtering (may include your own collections)")
 	} else {
@@ -100,81 +291,536 @@ func (m *Monitor) RunOnce() error {
 		log.Printf("✅ Authenticated as user ID: %s (filtering out your collections)", userID)
 	}
 
-	return m.runCheck()
+	err = m.runCheck(ctx)
+	m.flushEmailDigest()
+	return err
 }
 
-// runCheck performs a single monitoring check
-func (m *Monitor) runCheck() error {
-	log.Printf("⏰ Starting check at %s", time.Now().Format("2006-01-02 15:04:05"))
+// VerifyReport re-runs secret verification on a previously generated JSON report and
+// writes an updated copy, so old findings can be rechecked without searching Postman again.
+// Reports written with redaction are refused: their values can't be sent to providers.
+func (m *Monitor) VerifyReport(ctx context.Context, path string) (string, error) {
+	report, err := reporter.LoadReport(path)
+	if err != nil {
+		return "", err
+	}
+	if err := checkUnredacted(report); err != nil {
+		return "", fmt.Errorf("cannot re-verify %s: %w", path, err)
+	}
 
-	var allAlerts []notifier.Alert
+	if err := m.secretVerifier.CheckProxy(ctx); err != nil {
+		return "", err
+	}
 
-	// Search for each monitored keyword
-	for _, keyword := range m.config.MonitorKeywords {
-		log.Printf("🔎 Searching for keyword: %s", keyword)
+	log.Printf("🔁 Re-verifying secrets from %s (%d finding(s), %d secret(s))", path, len(report.Findings), report.TotalSecrets)
+	m.secretVerifier.StartRun()
 
-		// First, search via API (limited to accessible collections)
-		apiCollections, err := m.client.SearchCollectionsByQuery(keyword)
-		if err != nil {
-			log.Printf("⚠️  API search error for '%s': %v", keyword, err)
-		} else {
-			log.Printf("   API search: Found %d accessible collections", len(apiCollections))
+	activeCount := 0
+	report.BudgetSkipped = 0
+	report.RateLimited = 0
+	for i := range report.Findings {
+		finding := &report.Findings[i]
+		for j := range finding.Secrets {
+			detail := &finding.Secrets[j]
+
+			result := m.secretVerifier.VerifySecret(ctx, scanner.SecretMatch{
+				Type:     detail.Type,
+				RawValue: detail.Value,
+				Host:     detail.Host,
+				Metadata: detail.Metadata,
+			})
+
+			detail.IsVerified = !result.SkippedByPolicy && result.Status != scanner.StatusBudgetExceeded
+			detail.IsValid = result.IsValid
+			detail.RateLimited = result.RateLimited
+			detail.SkippedByPolicy = result.SkippedByPolicy
+			detail.VerifyAttempts = result.Attempts
+			detail.VerifyDetails = result.Details
+			detail.VerifyMsg = result.Message
+			detail.VerifyStatus = string(result.Status)
+			detail.Inconclusive = result.Inconclusive
+			if result.Severity != "" {
+				detail.Severity = result.Severity
+			}
+			if result.Type != "" {
+				detail.Type = result.Type
+			}
+
+			if result.IsValid {
+				activeCount++
+			}
+			if result.Status == scanner.StatusBudgetExceeded {
+				report.BudgetSkipped++
+			}
+			if result.RateLimited {
+				report.RateLimited++
+			}
+			slog.Info(fmt.Sprintf("   %s [%s] in %s: %s", finding.Name, detail.Type, detail.Location, result.Message),
+				"collection_id", finding.CollectionID, "secret_type", detail.Type, "active", result.IsValid, "inconclusive", result.Inconclusive)
 		}
+	}
+
+	log.Printf("📊 %d secret(s) still active", activeCount)
+	m.saveVerificationCache()
 
-		// Then, search via web scraping (finds ALL public collections)
-		log.Printf("   🌐 Web scraping Postman public search...")
-		scrapedCollections, err := m.webScraper.SearchPublicCollections(keyword)
+	return m.reporter.WriteVerifiedReport(report)
+}
+
+// checkUnredacted makes sure a report holds raw secret values. Reports from before the
+// redaction field was recorded are checked value by value.
+func checkUnredacted(report *reporter.Report) error {
+	if report.Redaction != "" && report.Redaction != string(scanner.RedactNone) {
+		return fmt.Errorf("it was written with %s redaction; re-verifying needs a report written with deep_scan.report_redaction: none", report.Redaction)
+	}
+	for _, finding := range report.Findings {
+		for _, detail := range finding.Secrets {
+			if scanner.LooksRedacted(detail.Value) {
+				return fmt.Errorf("the %s value in %s is redacted; re-verifying needs a report written with deep_scan.report_redaction: none", detail.Type, finding.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// ScanCollectionByID scans one known collection, skipping the keyword search, verifies its
+// secrets if enabled and writes the findings reports. No notifications are sent.
+func (m *Monitor) ScanCollectionByID(ctx context.Context, collectionID string) error {
+	slog.Info(fmt.Sprintf("🔬 Deep scanning collection %s for secrets", collectionID), "collection_id", collectionID)
+	m.secretVerifier.StartRun()
+
+	collectionData, err := m.client.GetCollectionAsMap(ctx, collectionID)
+	if err != nil {
+		metrics.APIErrors.Inc()
+		return fmt.Errorf("failed to fetch collection: %w", err)
+	}
+
+	col := postman.Collection{ID: collectionID}
+	collection := collectionData
+	if wrapped, ok := collectionData["collection"].(map[string]interface{}); ok {
+		collection = wrapped
+	}
+	if info, ok := collection["info"].(map[string]interface{}); ok {
+		col.Name, _ = info["name"].(string)
+		col.UID, _ = info["uid"].(string)
+	}
+
+	secrets, skipped := m.secretScanner.ScanCollection(collectionData)
+	logSkipped(skipped, "collection_id", collectionID)
+	metrics.CollectionsScanned.Inc()
+	for _, secret := range secrets {
+		metrics.SecretsFound.Inc(secret.Type)
+	}
+	slog.Info(fmt.Sprintf("   Found %d secret(s) in %s", len(secrets), col.Name),
+		"collection_id", col.ID, "collection_name", col.Name, "secrets", len(secrets))
+
+	if len(secrets) > 0 && m.config.DeepScan.VerifySecrets {
+		m.verifySecrets(ctx, secrets)
+	}
+
+	alerts := []notifier.Alert{{
+		Collection: col,
+		Secrets:    secrets,
+		Timestamp:  time.Now(),
+	}}
+	m.retryRateLimited(ctx, alerts)
+	m.generateScanReports(alerts)
+	return nil
+}
+
+// ScanWorkspaces scans the environments of every workspace the API key can access, where
+// real credentials are often kept, verifies their secrets if enabled and writes the
+// findings reports. Each environment with secrets becomes one finding. No notifications
+// are sent.
+func (m *Monitor) ScanWorkspaces(ctx context.Context) error {
+	workspaces, err := m.client.ListWorkspaces(ctx)
+	if err != nil {
+		metrics.APIErrors.Inc()
+		return fmt.Errorf("failed to list workspaces: %w", err)
+	}
+	log.Printf("🗂️  Scanning the environments of %d workspace(s)", len(workspaces))
+	m.secretVerifier.StartRun()
+
+	var alerts []notifier.Alert
+	environmentCount := 0
+	for _, workspace := range workspaces {
+		if ctx.Err() != nil {
+			log.Println("🛑 Shutdown requested - reporting the environments scanned so far")
+			break
+		}
+
+		environments, err := m.client.GetWorkspaceEnvironments(ctx, workspace.ID)
 		if err != nil {
-			log.Printf("⚠️  Web scraping error for '%s': %v", keyword, err)
-		} else {
-			log.Printf("   Web scraping: Found %d public collections", len(scrapedCollections))
+			metrics.APIErrors.Inc()
+			slog.Warn(fmt.Sprintf("   ⚠️  Could not list environments of workspace %s: %v", workspace.Name, err),
+				"workspace_id", workspace.ID, "error", err)
+			continue
 		}
 
-		// Convert scraped collections to standard format
-		var collections []postman.Collection
+		for _, environment := range environments {
+			if ctx.Err() != nil {
+				break
+			}
 
-		// Add API collections first
-		if apiCollections != nil {
-			collections = append(collections, apiCollections...)
+			environmentData, err := m.client.GetEnvironmentAsMap(ctx, environment.ID)
+			if err != nil {
+				metrics.APIErrors.Inc()
+				slog.Warn(fmt.Sprintf("   ⚠️  Could not fetch environment %s: %v", environment.Name, err),
+					"workspace_id", workspace.ID, "environment_id", environment.ID, "error", err)
+				continue
+			}
+			environmentCount++
+
+			alert := m.scanEnvironmentData(ctx, environmentData, postman.Collection{
+				ID:    environment.ID,
+				UID:   environment.UID,
+				Name:  fmt.Sprintf("%s (environment in %s)", environment.Name, workspace.Name),
+				Owner: environment.Owner,
+			}, "workspace_id", workspace.ID)
+			if len(alert.Secrets) == 0 {
+				continue
+			}
+			alert.Keyword = "workspace:" + workspace.Name
+			alert.IsPublic = environment.IsPublic || workspace.IsPublic()
+			alerts = append(alerts, alert)
 		}
+	}
 
-		// Add scraped collections (convert format)
-		seenURLs := make(map[string]bool)
-		for _, col := range collections {
-			seenURLs[col.ID] = true
+	log.Printf("📊 Scanned %d environment(s); %d contain secrets", environmentCount, len(alerts))
+	if len(alerts) > 0 {
+		m.retryRateLimited(ctx, alerts)
+		m.generateScanReports(alerts)
+	}
+	return nil
+}
+
+// ScanEnvironment scans one Postman environment - an exported JSON file, or the ID of an
+// environment fetched through the API - verifies its secrets if enabled and writes the
+// findings reports. No notifications are sent.
+func (m *Monitor) ScanEnvironment(ctx context.Context, fileOrID string) error {
+	var environmentData map[string]interface{}
+	if data, err := os.ReadFile(fileOrID); err == nil {
+		if err := json.Unmarshal(data, &environmentData); err != nil {
+			return fmt.Errorf("failed to parse environment %s: %w", fileOrID, err)
 		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read environment: %w", err)
+	} else {
+		environmentData, err = m.client.GetEnvironmentAsMap(ctx, fileOrID)
+		if err != nil {
+			metrics.APIErrors.Inc()
+			return fmt.Errorf("failed to fetch environment: %w", err)
+		}
+	}
+
+	environment := scanner.UnwrapEnvironment(environmentData)
+	if _, ok := environment["values"].([]interface{}); !ok {
+		return fmt.Errorf("%s is not a Postman environment (no values)", fileOrID)
+	}
+	env := postman.Collection{ID: fileOrID, Name: fileOrID}
+	if name, _ := environment["name"].(string); name != "" {
+		env.Name = name + " (environment)"
+	}
+	if id, _ := environment["id"].(string); id != "" {
+		env.ID = id
+	}
+
+	slog.Info(fmt.Sprintf("🔬 Scanning environment %s for secrets", env.Name), "environment_id", env.ID)
+	m.secretVerifier.StartRun()
+
+	alerts := []notifier.Alert{m.scanEnvironmentData(ctx, environmentData, env)}
+	m.retryRateLimited(ctx, alerts)
+	m.generateScanReports(alerts)
+	return nil
+}
+
+// scanEnvironmentData scans one environment, shown in findings as env, and verifies its
+// secrets if enabled. attrs are logged with its results.
+func (m *Monitor) scanEnvironmentData(ctx context.Context, environmentData map[string]interface{}, env postman.Collection, attrs ...any) notifier.Alert {
+	attrs = append(attrs, "environment_id", env.ID)
+	secrets, skipped := m.secretScanner.ScanEnvironment(environmentData)
+	logSkipped(skipped, attrs...)
+	for _, secret := range secrets {
+		metrics.SecretsFound.Inc(secret.Type)
+	}
+	slog.Info(fmt.Sprintf("   Found %d secret(s) in %s", len(secrets), env.Name), append(attrs, "secrets", len(secrets))...)
 
-		for _, scraped := range scrapedCollections {
-			// Skip if already found via API
-			collectionID := m.webScraper.GetCollectionID(scraped.URL)
-			if seenURLs[collectionID] {
+	if len(secrets) > 0 && m.config.DeepScan.VerifySecrets {
+		m.verifySecrets(ctx, secrets)
+	}
+
+	return notifier.Alert{
+		Collection: env,
+		Secrets:    secrets,
+		Timestamp:  time.Now(),
+	}
+}
+
+// verifySecrets checks each secret against its provider and records the result on it
+func (m *Monitor) verifySecrets(ctx context.Context, secrets []scanner.SecretMatch) {
+	if err := m.secretVerifier.CheckProxy(ctx); err != nil {
+		slog.Error(fmt.Sprintf("   ⚠️  Skipping verification of %d secret(s): %v", len(secrets), err), "secrets", len(secrets), "error", err)
+		return
+	}
+
+	log.Printf("   🔐 Verifying %d secret(s)...", len(secrets))
+	verifiedCount := 0
+	budgetSkipped := 0
+	for i, result := range m.secretVerifier.VerifyAll(ctx, secrets) {
+		if result == nil {
+			continue // Shutdown requested before this secret was verified
+		}
+		secrets[i].Verification = result
+		if result.Severity != "" {
+			secrets[i].Severity = result.Severity
+		}
+		if result.Type != "" {
+			secrets[i].Type = result.Type
+		}
+		if result.IsValid {
+			verifiedCount++
+			slog.Info(fmt.Sprintf("   ✅ Verified: %s - %s", secrets[i].Type, result.Message),
+				"secret_type", secrets[i].Type, "location", secrets[i].Location, "active", true)
+		} else if result.RateLimited {
+			slog.Info(fmt.Sprintf("   ⏸️  Rate limited: %s", secrets[i].Type),
+				"secret_type", secrets[i].Type, "location", secrets[i].Location, "rate_limited", true)
+		} else if result.Status == scanner.StatusBudgetExceeded {
+			budgetSkipped++
+		} else if result.SkippedByPolicy {
+			slog.Info(fmt.Sprintf("   ⏭️  Not verified (policy): %s", secrets[i].Type),
+				"secret_type", secrets[i].Type, "location", secrets[i].Location, "skipped_by_policy", true)
+		} else if result.Inconclusive {
+			slog.Warn(fmt.Sprintf("   ⚠️  Could not verify: %s - %s", secrets[i].Type, result.Message),
+				"secret_type", secrets[i].Type, "location", secrets[i].Location, "inconclusive", true, "verify_status", string(result.Status))
+		} else {
+			slog.Info(fmt.Sprintf("   ❌ Not active: %s - %s", secrets[i].Type, result.Message),
+				"secret_type", secrets[i].Type, "location", secrets[i].Location, "active", false, "verify_status", string(result.Status))
+		}
+	}
+	if budgetSkipped > 0 {
+		slog.Warn(fmt.Sprintf("   ⏭️  %d secret(s) not verified - verification budget exceeded", budgetSkipped),
+			"budget_skipped", budgetSkipped)
+	}
+	if verifiedCount > 0 {
+		slog.Error(fmt.Sprintf("   🚨 CRITICAL: %d ACTIVE secret(s) verified!", verifiedCount), "active", verifiedCount)
+	}
+	m.saveVerificationCache()
+}
+
+// retryRateLimited verifies once more the secrets providers rate limited this run, after
+// the latest reset time they gave (or deep_scan.rate_limit_retry_seconds after the check,
+// when they gave none), waiting at most deep_scan.rate_limit_max_wait_seconds. Results
+// are updated in place; secrets whose limit resets later are left rate limited. The retry
+// runs outside the run budget, so a spent budget can't turn them into budget_exceeded.
+func (m *Monitor) retryRateLimited(ctx context.Context, alerts []notifier.Alert) {
+	maxWait := time.Duration(m.config.DeepScan.RateLimitMaxWaitSeconds) * time.Second
+	if maxWait < 0 {
+		return
+	}
+	fallback := time.Duration(m.config.DeepScan.RateLimitRetrySeconds) * time.Second
+	deadline := time.Now().Add(maxWait)
+
+	var pending []*scanner.SecretMatch
+	var retryAt time.Time
+	tooLate := 0
+	for i := range alerts {
+		for j := range alerts[i].Secrets {
+			secret := &alerts[i].Secrets[j]
+			if secret.Verification == nil || !secret.Verification.RateLimited {
 				continue
 			}
+			at := secret.Verification.RetryAt
+			if at.IsZero() {
+				at = secret.Verification.VerifiedAt.Add(fallback)
+			}
+			if at.After(deadline) {
+				tooLate++
+				continue
+			}
+			if at.After(retryAt) {
+				retryAt = at
+			}
+			pending = append(pending, secret)
+		}
+	}
+	if tooLate > 0 {
+		log.Printf("⏸️  %d rate-limited secret(s) reset too late to retry within %s", tooLate, maxWait)
+	}
+	if len(pending) == 0 {
+		return
+	}
 
-			// Convert scraped collection to standard Collection format
-			collections = append(collections, postman.Collection{
-				ID:          collectionID,
-				Name:        scraped.Name,
-				Description: scraped.Description,
-				IsPublic:    true,
-				Owner:       scraped.Username, // This will be different from current user
-				Workspace:   scraped.Workspace,
-				UID:         scraped.URL,
-			})
+	if wait := time.Until(retryAt); wait > 0 {
+		log.Printf("⏸️  %d secret(s) were rate limited - re-verifying in %s", len(pending), wait.Round(time.Second))
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	} else {
+		log.Printf("⏸️  Re-verifying %d rate-limited secret(s)", len(pending))
+	}
+
+	secrets := make([]scanner.SecretMatch, len(pending))
+	for i, secret := range pending {
+		secrets[i] = *secret
+	}
+	// The run budget may be spent by now; these secrets were already counted against it
+	m.verifySecrets(scanner.OutsideRunBudget(ctx), secrets)
+
+	stillLimited := 0
+	for i, secret := range pending {
+		*secret = secrets[i]
+		if secret.Verification.RateLimited {
+			stillLimited++
 		}
+	}
+	if stillLimited > 0 {
+		slog.Warn(fmt.Sprintf("   ⏸️  Verification incomplete: %d secret(s) still rate limited", stillLimited),
+			"rate_limited", stillLimited)
+	}
+}
 
-		log.Printf("   Total unique collections: %d", len(collections))
+// saveVerificationCache persists verification results for the next run
+func (m *Monitor) saveVerificationCache() {
+	if err := m.secretVerifier.SaveCache(); err != nil {
+		slog.Warn(fmt.Sprintf("⚠️  Could not save verification cache: %v", err), "error", err)
+	}
+}
+
+// generateReports writes the findings reports in the configured formats (reports.formats),
+// the delta against the previous run's report only when there is one, and returns the
+// paths written
+func (m *Monitor) generateReports(alerts []notifier.Alert, duplicates map[string][]string) []string {
+	log.Println("📄 Generating findings reports...")
+
+	// Find the previous run's report before this run's is written, for the delta report
+	var previousReport string
+	if m.reporter.Writes(config.ReportDelta) {
+		var err error
+		if previousReport, err = m.reporter.LatestReport(); err != nil {
+			slog.Warn(fmt.Sprintf("⚠️  Could not find previous report: %v", err), "error", err)
+		}
+	}
+
+	var paths []string
+	write := func(format, name string, generate func() (string, error)) {
+		if !m.reporter.Writes(format) {
+			return
+		}
+		path, err := generate()
+		if err != nil {
+			slog.Error(fmt.Sprintf("⚠️  Failed to generate %s report: %v", name, err), "format", format, "error", err)
+			return
+		}
+		log.Printf("✅ %s report: %s", name, path)
+		paths = append(paths, path)
+	}
+
+	write(config.ReportJSON, "JSON", func() (string, error) {
+		return m.reporter.GenerateReport(alerts)
+	})
+	write(config.ReportHTML, "HTML", func() (string, error) {
+		return m.reporter.GenerateHTMLReport(alerts, duplicates)
+	})
+	write(config.ReportMarkdown, "Markdown", func() (string, error) {
+		return m.reporter.GenerateMarkdownReport(alerts, duplicates)
+	})
+	write(config.ReportCSV, "CSV", func() (string, error) {
+		return m.reporter.GenerateCSVReport(alerts, duplicates)
+	})
+	write(config.ReportSARIF, "SARIF", func() (string, error) {
+		return m.reporter.GenerateSARIFReport(alerts)
+	})
+
+	// Delta Report (only what changed since the previous run)
+	if previousReport != "" {
+		write(config.ReportDelta, "Delta", func() (string, error) {
+			return m.reporter.GenerateDeltaReport(alerts, previousReport)
+		})
+	}
+
+	return paths
+}
+
+// generateScanReports writes the reports of a single scan (-collection, -workspaces,
+// -env-scan), named scan_... and without a delta report, so they are never mistaken for
+// a check's
+func (m *Monitor) generateScanReports(alerts []notifier.Alert) []string {
+	m.reporter.SetSingleScan(true)
+	return m.generateReports(alerts, reporter.DetectDuplicateSecrets(alerts))
+}
+
+// flushEmailDigest sends any alerts still queued for the email digest before exiting
+func (m *Monitor) flushEmailDigest() {
+	if err := m.notifier.Flush(); err != nil {
+		slog.Error(fmt.Sprintf("❌ Failed to send queued email digest: %v", err), "error", err)
+	}
+}
+
+// logSkipped logs, at debug level, how many matches a scan dropped as placeholders or
+// for not looking random
+func logSkipped(skipped scanner.SkipCounts, args ...any) {
+	if skipped.Placeholders == 0 && skipped.LowEntropy == 0 {
+		return
+	}
+	slog.Debug(fmt.Sprintf("   🧩 Skipped %d placeholder match(es) ({{variables}}, :params and example values) and %d generic key=value match(es) that don't look random",
+		skipped.Placeholders, skipped.LowEntropy),
+		append(args, "placeholders_skipped", skipped.Placeholders, "low_entropy_skipped", skipped.LowEntropy)...)
+}
+
+// runCheck performs a single monitoring check. If ctx is cancelled mid-check, no new
+// collections are fetched but the alerts gathered so far are still reported.
+func (m *Monitor) runCheck(ctx context.Context) (err error) {
+	log.Printf("⏰ Starting check at %s", time.Now().Format("2006-01-02 15:04:05"))
+	m.secretVerifier.StartRun()
+
+	start := time.Now()
+	defer func() {
+		metrics.CheckDuration.Observe(time.Since(start).Seconds())
+		metrics.LastCheckTimestamp.Set(float64(time.Now().Unix()))
+		if err == nil {
+			m.lastSuccessfulCheck.Store(time.Now().Unix())
+		}
+	}()
+
+	var allAlerts []notifier.Alert
+
+	// Search for each monitored keyword
+	for _, keywordConfig := range m.config.MonitorKeywords {
+		if ctx.Err() != nil {
+			log.Println("🛑 Shutdown requested - finishing check with results gathered so far")
+			break
+		}
+
+		keyword := keywordConfig.Keyword
+		ignoreKeywords := m.config.IgnoreKeywordsFor(keywordConfig)
+		deepScan := m.config.DeepScanEnabledFor(keywordConfig)
+		verifySecrets := m.config.VerifySecretsFor(keywordConfig)
+
+		slog.Info(fmt.Sprintf("🔎 Searching for keyword: %s", keyword), "keyword", keyword)
+
+		collections := m.searchCollections(ctx, keyword)
+
+		slog.Info(fmt.Sprintf("   Total unique collections: %d", len(collections)), "keyword", keyword, "collections", len(collections))
 
 		// Filter and check each collection
 		for _, col := range collections {
+			if ctx.Err() != nil {
+				break
+			}
+
 			// Skip user's own collections
 			if m.currentUserID != "" && col.Owner == m.currentUserID {
-				log.Printf("   ⏭️  Skipping your own collection: %s (Owner: %s)", col.Name, col.Owner)
+				slog.Info(fmt.Sprintf("   ⏭️  Skipping your own collection: %s (Owner: %s)", col.Name, col.Owner),
+					"keyword", keyword, "collection_id", col.ID, "owner", col.Owner)
 				continue
 			}
 
-			if m.shouldIgnore(col) {
-				log.Printf("   ⏭️  Skipping ignored collection: %s", col.Name)
+			if shouldIgnore(col, ignoreKeywords) {
+				slog.Info(fmt.Sprintf("   ⏭️  Skipping ignored collection: %s", col.Name),
+					"keyword", keyword, "collection_id", col.ID, "collection_name", col.Name)
 				continue
 			}
 
@@ -188,37 +834,31 @@ func (m *Monitor) runCheck() error {
 
 			// Fetch full collection details and scan for secrets if deep scan is enabled
 			var secrets []scanner.SecretMatch
-			if m.config.DeepScan.Enabled {
-				log.Printf("   🔬 Deep scanning collection for secrets: %s", col.Name)
+			if deepScan {
+				slog.Info(fmt.Sprintf("   🔬 Deep scanning collection for secrets: %s", col.Name),
+					"keyword", keyword, "collection_id", col.ID, "collection_name", col.Name)
 
-				collectionData, err := m.client.GetCollectionAsMap(col.ID)
+				collectionData, err := m.client.GetCollectionAsMap(ctx, col.ID)
 				if err != nil {
-					log.Printf("   ⚠️  Could not fetch collection details for scanning: %v", err)
+					metrics.APIErrors.Inc()
+					slog.Warn(fmt.Sprintf("   ⚠️  Could not fetch collection details for scanning: %v", err),
+						"keyword", keyword, "collection_id", col.ID, "error", err)
 					// Continue with basic alert even if deep scan fails
 				} else {
-					secrets = m.secretScanner.ScanCollection(collectionData)
+					var skipped scanner.SkipCounts
+					secrets, skipped = m.secretScanner.ScanCollection(collectionData)
+					logSkipped(skipped, "keyword", keyword, "collection_id", col.ID)
+					metrics.CollectionsScanned.Inc()
+					for _, secret := range secrets {
+						metrics.SecretsFound.Inc(secret.Type)
+					}
 					if len(secrets) > 0 {
-						log.Printf("   ⚠️  Found %d secret(s) in collection!", len(secrets))
+						slog.Warn(fmt.Sprintf("   ⚠️  Found %d secret(s) in collection!", len(secrets)),
+							"keyword", keyword, "collection_id", col.ID, "secrets", len(secrets))
 
 						// Verify secrets if enabled
-						if m.config.DeepScan.VerifySecrets {
-							log.Printf("   🔐 Verifying %d secret(s)...", len(secrets))
-							verifiedCount := 0
-							for i := range secrets {
-								result := m.secretVerifier.VerifySecret(secrets[i])
-								secrets[i].Verification = result
-								if result.IsValid {
-									verifiedCount++
-									log.Printf("   ✅ Verified: %s - %s", secrets[i].Type, result.Message)
-								} else if result.RateLimited {
-									log.Printf("   ⏸️  Rate limited: %s", secrets[i].Type)
-								} else {
-									log.Printf("   ❌ Not active: %s - %s", secrets[i].Type, result.Message)
-								}
-							}
-							if verifiedCount > 0 {
-								log.Printf("   🚨 CRITICAL: %d ACTIVE secret(s) verified!", verifiedCount)
-							}
+						if verifySecrets {
+							m.verifySecrets(ctx, secrets)
 						}
 					}
 				}
@@ -234,7 +874,6 @@ func (m *Monitor) runCheck() error {
 			}
 
 			allAlerts = append(allAlerts, alert)
-			m.seenAlerts[alertKey] = time.Now()
 
 			// Log with explicit public exposure warning
 			if len(secrets) > 0 {
@@ -243,89 +882,73 @@ func (m *Monitor) runCheck() error {
 				for _, s := range secrets {
 					totalOccurrences += s.Occurrences
 				}
-				log.Printf("   🚨 CRITICAL: PUBLIC collection with %d unique secret(s) (%d total occurrences) - %s (ID: %s)", len(secrets), totalOccurrences, col.Name, col.ID)
+				slog.Error(fmt.Sprintf("   🚨 CRITICAL: PUBLIC collection with %d unique secret(s) (%d total occurrences) - %s (ID: %s)", len(secrets), totalOccurrences, col.Name, col.ID),
+					"keyword", keyword, "collection_id", col.ID, "collection_name", col.Name, "owner", col.Owner,
+					"secrets", len(secrets), "occurrences", totalOccurrences, "secret_types", secretTypes(secrets))
 			} else {
-				log.Printf("   ⚠️  WARNING: PUBLIC collection found (no secrets detected) - %s (ID: %s)", col.Name, col.ID)
+				slog.Warn(fmt.Sprintf("   ⚠️  WARNING: PUBLIC collection found (no secrets detected) - %s (ID: %s)", col.Name, col.ID),
+					"keyword", keyword, "collection_id", col.ID, "collection_name", col.Name, "owner", col.Owner)
 			}
 		}
 	}
 
 	// Send notifications if there are new alerts
 	if len(allAlerts) > 0 {
-		// Count critical vs warning alerts
-		criticalCount := 0
-		warningCount := 0
-		for _, alert := range allAlerts {
-			if len(alert.Secrets) > 0 {
-				criticalCount++
-			} else {
-				warningCount++
-			}
-		}
+		criticalCount, warningCount := countSeverity(allAlerts)
 
 		log.Printf("📊 Summary: %d CRITICAL (with secrets), %d WARNING (public only)", criticalCount, warningCount)
 
-		if m.dryRun {
-			log.Printf("🧪 DRY-RUN: Would send %d alert(s) via email (skipped)", len(allAlerts))
-			for i, alert := range allAlerts {
-				severity := "WARNING"
-				if len(alert.Secrets) > 0 {
-					severity = "CRITICAL"
-				}
-				log.Printf("   [%s] Alert %d: %s (Keyword: %s, Secrets: %d)",
-					severity, i+1, alert.Collection.Name, alert.Keyword, len(alert.Secrets))
-			}
-		} else if !m.config.HasEmailConfigured() {
-			log.Printf("⚠️  Email not configured - %d alert(s) detected but not sent", len(allAlerts))
-			log.Println("📝 Alerts logged to file only. Configure email in config.yaml to receive alerts.")
-			for i, alert := range allAlerts {
-				severity := "WARNING"
-				if len(alert.Secrets) > 0 {
-					severity = "CRITICAL"
-				}
-				log.Printf("   [%s] Alert %d: %s (Keyword: %s, Secrets: %d)",
-					severity, i+1, alert.Collection.Name, alert.Keyword, len(alert.Secrets))
-			}
-		} else {
-			log.Printf("📧 Sending %d alert(s) via email (%d critical, %d warning)", len(allAlerts), criticalCount, warningCount)
-			if err := m.notifier.SendAlert(allAlerts); err != nil {
-				log.Printf("❌ Failed to send email notification: %v", err)
-				return err
-			}
-			log.Println("✅ Alert email sent successfully")
-		}
+		m.retryRateLimited(ctx, allAlerts)
 
 		// Detect duplicate secrets
 		duplicates := reporter.DetectDuplicateSecrets(allAlerts)
 		if len(duplicates) > 0 {
-			log.Printf("⚠️  Found %d duplicate secret(s) across multiple collections!", len(duplicates))
+			slog.Warn(fmt.Sprintf("⚠️  Found %d duplicate secret(s) across multiple collections!", len(duplicates)), "duplicates", len(duplicates))
 		}
 
-		// Generate reports in all formats
-		log.Println("📄 Generating findings reports...")
+		// Reports are written before notifying, so the alert email can attach them. They are
+		// written in every mode - dry runs and runs without email included - so there is
+		// always something to review afterwards.
+		reports := m.generateReports(allAlerts, duplicates)
 
-		// JSON Report
-		jsonPath, err := m.reporter.GenerateReport(allAlerts)
-		if err != nil {
-			log.Printf("⚠️  Failed to generate JSON report: %v", err)
-		} else {
-			log.Printf("✅ JSON report: %s", jsonPath)
+		// Reports hold every finding; monitoring.alert_on chooses the ones that notify
+		alerts := notifier.FilterAlerts(allAlerts, m.config.Monitoring.AlertOn)
+		if held := len(allAlerts) - len(alerts); held > 0 {
+			log.Printf("🔕 %d alert(s) not sent (alert_on: %s) - they are in the reports only", held, m.config.Monitoring.AlertOn)
 		}
+		m.markSeen(allAlerts, alerts)
+		criticalCount, warningCount = countSeverity(alerts)
 
-		// HTML Report
-		htmlPath, err := m.reporter.GenerateHTMLReport(allAlerts, duplicates)
-		if err != nil {
-			log.Printf("⚠️  Failed to generate HTML report: %v", err)
-		} else {
-			log.Printf("✅ HTML report: %s", htmlPath)
+		// Discord notifications are independent of email
+		if m.config.Discord.WebhookURL != "" && !m.dryRun && len(alerts) > 0 {
+			log.Printf("💬 Sending %d alert(s) to Discord", len(alerts))
+			if err := m.discord.SendAlert(alerts); err != nil {
+				slog.Error(fmt.Sprintf("❌ Failed to send Discord notification: %v", err), "alerts", len(alerts), "error", err)
+			} else {
+				log.Println("✅ Discord notification sent successfully")
+			}
 		}
 
-		// Markdown Report
-		mdPath, err := m.reporter.GenerateMarkdownReport(allAlerts, duplicates)
-		if err != nil {
-			log.Printf("⚠️  Failed to generate Markdown report: %v", err)
+		if len(alerts) == 0 {
+			log.Println("✅ No alerts to send")
+		} else if m.dryRun {
+			log.Printf("🧪 DRY-RUN: Would send %d alert(s) via email (skipped) - see the dryrun_ reports", len(alerts))
+			logAlerts(alerts)
+		} else if !m.config.HasEmailConfigured() {
+			slog.Warn(fmt.Sprintf("⚠️  Email not configured - %d alert(s) detected but not sent", len(alerts)), "alerts", len(alerts))
+			log.Println("📝 Alerts logged to file only. Configure email in config.yaml to receive alerts.")
+			logAlerts(alerts)
 		} else {
-			log.Printf("✅ Markdown report: %s", mdPath)
+			log.Printf("📧 Sending %d alert(s) via email (%d critical, %d warning)", len(alerts), criticalCount, warningCount)
+			if err := m.notifier.SendAlert(alerts, reports); err != nil {
+				slog.Error(fmt.Sprintf("❌ Failed to send email notification: %v", err), "alerts", len(alerts), "error", err)
+				return err
+			}
+			if m.config.Email.MinIntervalMinutes > 0 {
+				log.Println("✅ Alerts handed to the email digest")
+			} else {
+				log.Println("✅ Alert email sent successfully")
+			}
 		}
 	} else {
 		log.Println("✅ No new public collections found")
@@ -338,12 +961,97 @@ func (m *Monitor) runCheck() error {
 	return nil
 }
 
+// logAlerts lists alerts that were not sent, so the log shows what would have gone out
+func logAlerts(alerts []notifier.Alert) {
+	for i, alert := range alerts {
+		severity := "WARNING"
+		if len(alert.Secrets) > 0 {
+			severity = "CRITICAL"
+		}
+		log.Printf("   [%s] Alert %d: %s (Keyword: %s, Secrets: %d)",
+			severity, i+1, alert.Collection.Name, alert.Keyword, len(alert.Secrets))
+	}
+}
+
+// countSeverity counts CRITICAL alerts (with secrets) and WARNING alerts (public only)
+func countSeverity(alerts []notifier.Alert) (critical, warning int) {
+	for _, alert := range alerts {
+		if len(alert.Secrets) > 0 {
+			critical++
+		} else {
+			warning++
+		}
+	}
+	return critical, warning
+}
+
+// searchCollections finds collections for a keyword via the Postman API and, when
+// enabled, Postman's public web search, merged and deduplicated by collection ID
+func (m *Monitor) searchCollections(ctx context.Context, keyword string) []postman.Collection {
+	// First, search via API (limited to accessible collections)
+	apiCollections, err := m.client.SearchCollectionsByQuery(ctx, keyword)
+	if err != nil {
+		metrics.APIErrors.Inc()
+		slog.Warn(fmt.Sprintf("⚠️  API search error for '%s': %v", keyword, err), "keyword", keyword, "error", err)
+	} else {
+		slog.Info(fmt.Sprintf("   API search: Found %d accessible collections", len(apiCollections)), "keyword", keyword, "collections", len(apiCollections))
+	}
+
+	// Add API collections first
+	var collections []postman.Collection
+	if apiCollections != nil {
+		collections = append(collections, apiCollections...)
+	}
+
+	if !m.config.WebScraperEnabled() {
+		return collections
+	}
+
+	// Then, search via web scraping (finds ALL public collections)
+	log.Printf("   🌐 Web scraping Postman public search...")
+	scrapedCollections, err := m.webScraper.SearchPublicCollections(ctx, keyword)
+	if err != nil {
+		metrics.APIErrors.Inc()
+		slog.Warn(fmt.Sprintf("⚠️  Web scraping error for '%s': %v", keyword, err), "keyword", keyword, "error", err)
+	} else {
+		slog.Info(fmt.Sprintf("   Web scraping: Found %d public collections", len(scrapedCollections)), "keyword", keyword, "collections", len(scrapedCollections))
+	}
+
+	// Add scraped collections (convert format)
+	seenIDs := make(map[string]bool)
+	for _, col := range collections {
+		seenIDs[col.ID] = true
+	}
+
+	for _, scraped := range scrapedCollections {
+		// Skip if already found via API or the ID can't be resolved
+		collectionID := m.webScraper.GetCollectionID(scraped.URL)
+		if collectionID == "" || seenIDs[collectionID] {
+			continue
+		}
+		seenIDs[collectionID] = true
+
+		// Convert scraped collection to standard Collection format
+		collections = append(collections, postman.Collection{
+			ID:          collectionID,
+			Name:        scraped.Name,
+			Description: scraped.Description,
+			IsPublic:    true,
+			Owner:       scraped.Username, // This will be different from current user
+			Workspace:   scraped.Workspace,
+			UID:         scraped.URL,
+		})
+	}
+
+	return collections
+}
+
 // shouldIgnore checks if a collection should be ignored based on ignore keywords
-func (m *Monitor) shouldIgnore(col postman.Collection) bool {
+func shouldIgnore(col postman.Collection, ignoreKeywords []string) bool {
 	name := strings.ToLower(col.Name)
 	description := strings.ToLower(col.Description)
 
-	for _, ignoreKeyword := range m.config.IgnoreKeywords {
+	for _, ignoreKeyword := range ignoreKeywords {
 		keyword := strings.ToLower(ignoreKeyword)
 		if strings.Contains(name, keyword) || strings.Contains(description, keyword) {
 			return true
@@ -353,6 +1061,40 @@ func (m *Monitor) shouldIgnore(col postman.Collection) bool {
 	return false
 }
 
+// markSeen records the collections not to alert about again for a week: the ones that
+// notify, and held-back ones whose secrets all got a conclusive answer. A collection held
+// back while a verification was rate limited or failed is scanned again next run, so a
+// secret that turns out active still notifies.
+func (m *Monitor) markSeen(all, notified []notifier.Alert) {
+	sent := make(map[string]bool, len(notified))
+	for _, alert := range notified {
+		sent[seenKey(alert)] = true
+	}
+
+	now := time.Now()
+	for _, alert := range all {
+		if key := seenKey(alert); sent[key] || !hasInconclusiveSecret(alert) {
+			m.seenAlerts[key] = now
+		}
+	}
+}
+
+// seenKey identifies an alert in the seen alerts map
+func seenKey(alert notifier.Alert) string {
+	return fmt.Sprintf("%s:%s", alert.Keyword, alert.Collection.ID)
+}
+
+// hasInconclusiveSecret reports whether any of an alert's secrets could not be verified
+// either way
+func hasInconclusiveSecret(alert notifier.Alert) bool {
+	for _, secret := range alert.Secrets {
+		if secret.Verification != nil && secret.Verification.Inconclusive {
+			return true
+		}
+	}
+	return false
+}
+
 // cleanupSeenAlerts removes old entries from the seen alerts map
 func (m *Monitor) cleanupSeenAlerts() {
 	cutoff := time.Now().Add(-30 * 24 * time.Hour)
@@ -362,3 +1104,16 @@ func (m *Monitor) cleanupSeenAlerts() {
 		}
 	}
 }
+
+// secretTypes lists the distinct types of secrets, in the order first found
+func secretTypes(secrets []scanner.SecretMatch) []string {
+	var types []string
+	seen := make(map[string]bool)
+	for _, secret := range secrets {
+		if !seen[secret.Type] {
+			seen[secret.Type] = true
+			types = append(types, secret.Type)
+		}
+	}
+	return types
+}
@@ -0,0 +1,176 @@
+package observer
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/yourusername/postman-observer/config"
+	"github.com/yourusername/postman-observer/notifier"
+	"github.com/yourusername/postman-observer/postman"
+	"github.com/yourusername/postman-observer/reporter"
+	"github.com/yourusername/postman-observer/scanner"
+	"github.com/yourusername/postman-observer/schedule"
+)
+
+// newTestMonitor builds a monitor whose reports, state and audit log live in a temporary
+// directory
+func newTestMonitor(t *testing.T) *Monitor {
+	t.Helper()
+	dir := t.TempDir()
+	cfg := &config.Config{MonitorKeywords: []config.KeywordConfig{{Keyword: "acme"}}}
+	cfg.Reports.Dir = filepath.Join(dir, "reports")
+	cfg.State.Dir = filepath.Join(dir, "state")
+	cfg.DeepScan.AuditLog = filepath.Join(dir, "audit.jsonl")
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	m := NewMonitor(cfg)
+	t.Cleanup(func() { m.Close() })
+	return m
+}
+
+// writeReport saves a report as JSON and returns its path
+func writeReport(t *testing.T, report reporter.Report) string {
+	t.Helper()
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "findings.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestVerifyReportRefusesRedactedReports(t *testing.T) {
+	tests := []struct {
+		name      string
+		redaction string
+		value     string
+		wantErr   bool
+	}{
+		{"raw", "none", "internal-token-value-1234567890", false},
+		{"partial", "partial", "inte**********7890", true},
+		{"full", "full", "[Internal Token sha256:0123456789ab]", true},
+		{"legacy report, raw", "", "internal-token-value-1234567890", false},
+		{"legacy report, partial", "", "inte**********7890", true},
+		{"legacy report, full", "", "[Internal Token sha256:0123456789ab]", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestMonitor(t)
+			path := writeReport(t, reporter.Report{
+				Redaction: tt.redaction,
+				Findings: []reporter.Finding{{
+					Name:    "Acme API",
+					Secrets: []reporter.SecretDetail{{Type: "Internal Token", Value: tt.value}},
+				}},
+			})
+
+			written, err := m.VerifyReport(context.Background(), path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyReport() error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			report, err := reporter.LoadReport(written)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status := report.Findings[0].Secrets[0].VerifyStatus; status != "unsupported" {
+				t.Errorf("verify_status = %q, want unsupported", status)
+			}
+		})
+	}
+}
+
+func TestVerifyReportStopsWithContext(t *testing.T) {
+	m := newTestMonitor(t)
+	m.secretVerifier.RequireProxy("127.0.0.1:1") // Nothing listens there
+	path := writeReport(t, reporter.Report{Redaction: "none"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := m.VerifyReport(ctx, path); err == nil {
+		t.Fatal("VerifyReport() with a cancelled context and an unreachable proxy succeeded")
+	}
+}
+
+func TestStartStop(t *testing.T) {
+	tests := []struct {
+		name        string
+		stopFirst   bool
+		cancelStart bool
+	}{
+		{"stop while running", false, false},
+		{"stop before start", true, false},
+		{"start's context cancelled", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestMonitor(t)
+			// A schedule far away, so the loop only waits and no check runs
+			m.config.Monitoring.Schedule = "0 0 1 1 *"
+			m.schedule, _ = schedule.Parse(m.config.Monitoring.Schedule)
+
+			if tt.stopFirst {
+				m.Stop()
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			returned := make(chan struct{})
+			go func() {
+				m.Start(ctx)
+				close(returned)
+			}()
+
+			if !tt.stopFirst {
+				time.Sleep(10 * time.Millisecond) // Let Start reach its wait
+				if tt.cancelStart {
+					cancel()
+				} else {
+					m.Stop()
+				}
+			}
+			select {
+			case <-returned:
+			case <-time.After(5 * time.Second):
+				t.Fatal("Start didn't return")
+			}
+			m.Stop() // Safe after the loop has exited
+		})
+	}
+}
+
+func TestMarkSeenKeepsInconclusiveHeldAlerts(t *testing.T) {
+	m := newTestMonitor(t)
+	verified := func(status scanner.VerificationStatus) []scanner.SecretMatch {
+		return []scanner.SecretMatch{{Type: "GitHub Token", Verification: &scanner.VerificationResult{
+			Status: status, IsValid: status == scanner.StatusActive, Inconclusive: status.Inconclusive(),
+		}}}
+	}
+	alert := func(id string, secrets []scanner.SecretMatch) notifier.Alert {
+		return notifier.Alert{Keyword: "acme", Collection: postman.Collection{ID: id}, Secrets: secrets}
+	}
+	all := []notifier.Alert{
+		alert("active", verified(scanner.StatusActive)),
+		alert("invalid", verified(scanner.StatusInvalid)),
+		alert("rate-limited", verified(scanner.StatusRateLimited)),
+		alert("public-only", nil),
+	}
+
+	m.markSeen(all, notifier.FilterAlerts(all, config.AlertOnVerifiedActive))
+
+	for id, want := range map[string]bool{"active": true, "invalid": true, "rate-limited": false, "public-only": true} {
+		if _, seen := m.seenAlerts["acme:"+id]; seen != want {
+			t.Errorf("%s marked seen = %t, want %t", id, seen, want)
+		}
+	}
+}
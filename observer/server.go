@@ -0,0 +1,97 @@
+package observer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/yourusername/postman-observer/metrics"
+)
+
+// httpHandlers returns the configured HTTP endpoints grouped by listen address; metrics
+// and health checks share one server when they are configured on the same address
+func (m *Monitor) httpHandlers() map[string]*http.ServeMux {
+	muxes := make(map[string]*http.ServeMux)
+	muxFor := func(addr string) *http.ServeMux {
+		if muxes[addr] == nil {
+			muxes[addr] = http.NewServeMux()
+		}
+		return muxes[addr]
+	}
+
+	if addr := m.config.Metrics.Addr; addr != "" {
+		muxFor(addr).Handle("/metrics", metrics.Handler())
+		log.Printf("📈 Metrics available at http://%s/metrics", addr)
+	}
+
+	if addr := m.config.Health.Addr; addr != "" {
+		mux := muxFor(addr)
+		mux.HandleFunc("/healthz", m.handleHealthz)
+		mux.HandleFunc("/readyz", m.handleReadyz)
+		log.Printf("💓 Health checks available at http://%s/healthz and /readyz", addr)
+	}
+
+	return muxes
+}
+
+// serveHTTP runs an HTTP server on addr until ctx is cancelled
+func (m *Monitor) serveHTTP(ctx context.Context, addr string, handler http.Handler) {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		slog.Error(fmt.Sprintf("⚠️  HTTP server on %s stopped: %v", addr, err), "addr", addr, "error", err)
+	}
+}
+
+// handleHealthz is the liveness probe: the process is up and serving
+func (m *Monitor) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok\n"))
+}
+
+// handleReadyz is the readiness probe: a check has succeeded, and recently enough that the
+// monitoring loop isn't wedged - within two check intervals, i.e. before the second
+// check due after it. A schedule's first check waits for its slot, which may be days
+// away, so until then the start counts as the last check.
+func (m *Monitor) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	lastSuccess := m.lastSuccessfulCheck.Load()
+	if lastSuccess == 0 {
+		started := m.startedAt.Load()
+		if m.schedule == nil || started == 0 {
+			http.Error(w, "no successful check yet", http.StatusServiceUnavailable)
+			return
+		}
+		if deadline := m.nextCheck(m.nextCheck(time.Unix(started, 0))); time.Now().After(deadline) {
+			http.Error(w, "no successful check since the first scheduled one", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ready (waiting for the first scheduled check)\n"))
+		return
+	}
+
+	lastCheck := time.Unix(lastSuccess, 0)
+	if deadline := m.nextCheck(m.nextCheck(lastCheck)); time.Now().After(deadline) {
+		age := time.Since(lastCheck).Round(time.Second)
+		http.Error(w, "last successful check was "+age.String()+" ago", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ready\n"))
+}
@@ -0,0 +1,52 @@
+package observer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yourusername/postman-observer/schedule"
+)
+
+func TestReadyz(t *testing.T) {
+	daily, err := schedule.Parse("0 3 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		schedule    *schedule.Schedule
+		started     time.Duration // How long ago Start began; 0 if it hasn't
+		lastSuccess time.Duration // How long ago a check succeeded; 0 if none has
+		want        int
+	}{
+		{"not started", nil, 0, 0, http.StatusServiceUnavailable},
+		{"startup check pending", nil, time.Minute, 0, http.StatusServiceUnavailable},
+		{"recent check", nil, 2 * time.Hour, time.Hour, http.StatusOK},
+		{"stale check", nil, 100 * time.Hour, 50 * time.Hour, http.StatusServiceUnavailable},
+		{"scheduled, first slot pending", daily, time.Minute, 0, http.StatusOK},
+		{"scheduled, first check never succeeded", daily, 72 * time.Hour, 0, http.StatusServiceUnavailable},
+		{"scheduled, recent check", daily, 72 * time.Hour, 20 * time.Hour, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestMonitor(t)
+			m.config.Monitoring.IntervalHours = 24
+			m.schedule = tt.schedule
+			if tt.started > 0 {
+				m.startedAt.Store(time.Now().Add(-tt.started).Unix())
+			}
+			if tt.lastSuccess > 0 {
+				m.lastSuccessfulCheck.Store(time.Now().Add(-tt.lastSuccess).Unix())
+			}
+
+			recorder := httptest.NewRecorder()
+			m.handleReadyz(recorder, httptest.NewRequest("GET", "/readyz", nil))
+			if recorder.Code != tt.want {
+				t.Errorf("/readyz = %d (%s), want %d", recorder.Code, recorder.Body.String(), tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package postman
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -8,6 +9,9 @@ import (
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/yourusername/postman-observer/config"
+	"github.com/yourusername/postman-observer/retryafter"
 )
 
 const (
@@ -19,6 +23,7 @@ type Client struct {
 	apiKey      string
 	httpClient  *http.Client
 	rateLimiter *time.Ticker
+	maxRetries  int // Retries on HTTP 429 before giving up
 }
 
 // Collection represents a Postman collection
@@ -60,35 +65,55 @@ type DetailedCollection struct {
 }
 
 // NewClient creates a new Postman API client
-func NewClient(apiKey string) *Client {
-	return &Client{
+func NewClient(apiKey string, cfg config.PostmanConfig) *Client {
+	client := &Client{
 		apiKey: apiKey,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		rateLimiter: time.NewTicker(500 * time.Millisecond), // 2 requests per second max
+		maxRetries: cfg.MaxRetries,
+	}
+
+	// A nil rate limiter disables throttling
+	if !cfg.DisableRateLimit && cfg.RateLimitPerSecond > 0 {
+		// Rates beyond 1e9/s would make the interval 0, which NewTicker rejects
+		client.rateLimiter = time.NewTicker(max(time.Second/time.Duration(cfg.RateLimitPerSecond), time.Nanosecond))
+	}
+
+	return client
+}
+
+// SetTransport sends API requests through transport (e.g. one with a proxy)
+func (c *Client) SetTransport(transport http.RoundTripper) {
+	c.httpClient.Transport = transport
+}
+
+// SetTimeout sets how long one API request may take (default 30s)
+func (c *Client) SetTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		c.httpClient.Timeout = timeout
 	}
 }
 
 // GetCurrentUser retrieves the authenticated user's information
-func (c *Client) GetCurrentUser() (string, error) {
+func (c *Client) GetCurrentUser(ctx context.Context) (string, error) {
 	// Skip if no API key provided
 	if c.apiKey == "" {
 		return "", fmt.Errorf("no API key provided - user filtering disabled")
 	}
 
-	c.waitForRateLimit()
+	c.waitForRateLimit(ctx)
 
 	endpoint := fmt.Sprintf("%s/me", baseURL)
 
-	req, err := http.NewRequest("GET", endpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("X-API-Key", c.apiKey)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doWithRetry(req)
 	if err != nil {
 		return "", fmt.Errorf("request failed: %w", err)
 	}
@@ -114,19 +139,70 @@ func (c *Client) GetCurrentUser() (string, error) {
 }
 
 // waitForRateLimit waits for rate limiter before making API call
-func (c *Client) waitForRateLimit() {
+func (c *Client) waitForRateLimit(ctx context.Context) {
 	if c.rateLimiter != nil {
-		<-c.rateLimiter.C
+		select {
+		case <-c.rateLimiter.C:
+		case <-ctx.Done():
+		}
+	}
+}
+
+// doWithRetry sends a request, retrying on HTTP 429 after the Retry-After delay
+// (or exponential backoff when the header is missing) up to maxRetries times
+func (c *Client) doWithRetry(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusTooManyRequests || attempt >= c.maxRetries {
+			return resp, nil
+		}
+
+		delay := retryDelay(resp.Header.Get("Retry-After"), attempt)
+		resp.Body.Close()
+
+		// Rewind the body for requests that carry one
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+			req.Body = body
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
 	}
 }
 
+// maxRetryDelay caps the wait before a retry, however long Retry-After asks for
+const maxRetryDelay = 60 * time.Second
+
+// retryDelay is how long to wait before retrying after a 429: what Retry-After asks for,
+// or exponential backoff starting at one second when the header is missing, at most
+// maxRetryDelay
+func retryDelay(header string, attempt int) time.Duration {
+	if delay, ok := retryafter.Parse(header); ok {
+		return min(delay, maxRetryDelay)
+	}
+	return min(time.Second<<min(attempt, 6), maxRetryDelay)
+}
+
 // SearchPublicCollections searches for public collections by keyword
-func (c *Client) SearchPublicCollections(keyword string) ([]Collection, error) {
-	c.waitForRateLimit() // Rate limit API calls
+func (c *Client) SearchPublicCollections(ctx context.Context, keyword string) ([]Collection, error) {
+	c.waitForRateLimit(ctx) // Rate limit API calls
 
 	endpoint := fmt.Sprintf("%s/collections", baseURL)
 
-	req, err := http.NewRequest("GET", endpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -174,21 +250,21 @@ func (c *Client) SearchPublicCollections(keyword string) ([]Collection, error) {
 // SearchCollectionsByQuery searches collections accessible to the API key
 // Note: Postman API limitation - cannot search ALL public collections
 // This lists YOUR accessible collections and filters by keyword locally
-func (c *Client) SearchCollectionsByQuery(query string) ([]Collection, error) {
+func (c *Client) SearchCollectionsByQuery(ctx context.Context, query string) ([]Collection, error) {
 	// Postman API does not provide a public search endpoint
 	// We list all accessible collections and filter locally
-	c.waitForRateLimit() // Rate limit API calls
+	c.waitForRateLimit(ctx) // Rate limit API calls
 
 	endpoint := fmt.Sprintf("%s/collections", baseURL)
 
-	req, err := http.NewRequest("GET", endpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("X-API-Key", c.apiKey)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doWithRetry(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -224,12 +300,12 @@ func (c *Client) SearchCollectionsByQuery(query string) ([]Collection, error) {
 }
 
 // GetCollectionDetails retrieves detailed information about a collection
-func (c *Client) GetCollectionDetails(collectionID string) (*DetailedCollection, error) {
-	c.waitForRateLimit() // Rate limit API calls
+func (c *Client) GetCollectionDetails(ctx context.Context, collectionID string) (*DetailedCollection, error) {
+	c.waitForRateLimit(ctx) // Rate limit API calls
 
 	endpoint := fmt.Sprintf("%s/collections/%s", baseURL, url.PathEscape(collectionID))
 
-	req, err := http.NewRequest("GET", endpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -256,10 +332,10 @@ func (c *Client) GetCollectionDetails(collectionID string) (*DetailedCollection,
 }
 
 // GetCollectionAsMap retrieves collection details as a raw map for scanning
-func (c *Client) GetCollectionAsMap(collectionID string) (map[string]interface{}, error) {
+func (c *Client) GetCollectionAsMap(ctx context.Context, collectionID string) (map[string]interface{}, error) {
 	endpoint := fmt.Sprintf("%s/collections/%s", baseURL, url.PathEscape(collectionID))
 
-	req, err := http.NewRequest("GET", endpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -269,7 +345,7 @@ func (c *Client) GetCollectionAsMap(collectionID string) (map[string]interface{}
 		req.Header.Set("X-API-Key", c.apiKey)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doWithRetry(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -277,7 +353,7 @@ func (c *Client) GetCollectionAsMap(collectionID string) (map[string]interface{}
 
 	// If 401 and no API key, try public API endpoint
 	if resp.StatusCode == http.StatusUnauthorized && c.apiKey == "" {
-		return c.getPublicCollection(collectionID)
+		return c.getPublicCollection(ctx, collectionID)
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -294,11 +370,11 @@ func (c *Client) GetCollectionAsMap(collectionID string) (map[string]interface{}
 }
 
 // getPublicCollection attempts to fetch a public collection without authentication
-func (c *Client) getPublicCollection(collectionID string) (map[string]interface{}, error) {
+func (c *Client) getPublicCollection(ctx context.Context, collectionID string) (map[string]interface{}, error) {
 	// Try Postman's public API endpoint (no auth required for public collections)
 	publicEndpoint := fmt.Sprintf("https://www.postman.com/_api/collection/%s", collectionID)
 
-	req, err := http.NewRequest("GET", publicEndpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", publicEndpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create public request: %w", err)
 	}
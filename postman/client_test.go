@@ -0,0 +1,79 @@
+package postman
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yourusername/postman-observer/config"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		attempt int
+		want    time.Duration
+	}{
+		{"seconds", "5", 0, 5 * time.Second},
+		{"clamped", "86400", 0, maxRetryDelay},
+		{"clamped date", time.Now().Add(2 * time.Hour).UTC().Format(http.TimeFormat), 0, maxRetryDelay},
+		{"backoff", "", 2, 4 * time.Second},
+		{"backoff clamped", "", 40, maxRetryDelay},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryDelay(tt.header, tt.attempt); got != tt.want {
+				t.Errorf("retryDelay(%q, %d) = %s, want %s", tt.header, tt.attempt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoWithRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxRetries   int
+		wantRequests int32
+		wantStatus   int
+	}{
+		{"no retries", 0, 1, http.StatusTooManyRequests},
+		{"retried", 2, 2, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) == 1 {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(http.StatusTooManyRequests)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient("PMAK-test", config.PostmanConfig{MaxRetries: tt.maxRetries})
+			req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+			resp, err := client.doWithRetry(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus || requests.Load() != tt.wantRequests {
+				t.Errorf("status %d after %d requests, want %d after %d", resp.StatusCode, requests.Load(), tt.wantStatus, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestNewClientWithHugeRateLimit(t *testing.T) {
+	// One request per nanosecond or faster; the ticker interval must not round to zero
+	client := NewClient("PMAK-test", config.PostmanConfig{RateLimitPerSecond: 2_000_000_000})
+	if client.rateLimiter == nil {
+		t.Fatal("rate limiter not set")
+	}
+	client.rateLimiter.Stop()
+}
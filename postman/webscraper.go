@@ -1,6 +1,7 @@
 package postman
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,6 +14,7 @@ import (
 type WebScraper struct {
 	httpClient  *http.Client
 	rateLimiter *time.Ticker
+	maxResults  int // Cap on search results read per keyword (0 = no cap)
 }
 
 // ScrapedCollection represents a collection found via web scraping
@@ -24,21 +26,67 @@ type ScrapedCollection struct {
 	Workspace   string
 }
 
-// NewWebScraper creates a new Postman web scraper
-func NewWebScraper() *WebScraper {
+// NewWebScraper creates a new Postman web scraper that reads up to maxResults
+// search results per keyword (0 = all pages)
+func NewWebScraper(maxResults int) *WebScraper {
 	return &WebScraper{
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
 		rateLimiter: time.NewTicker(2 * time.Second), // More conservative for web scraping
+		maxResults:  maxResults,
 	}
 }
 
+// SetTransport sends search requests through transport (e.g. one with a proxy)
+func (ws *WebScraper) SetTransport(transport http.RoundTripper) {
+	ws.httpClient.Transport = transport
+}
+
+// SetTimeout sets how long one search request may take (default 30s)
+func (ws *WebScraper) SetTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		ws.httpClient.Timeout = timeout
+	}
+}
+
+// searchPageSize is the maximum page size allowed by Postman's search API
+const searchPageSize = 25
+
 // SearchPublicCollections searches for public Postman collections using Postman's native search API
 // This uses the same endpoint that the Postman web UI uses: /_api/ws/proxy
-func (ws *WebScraper) SearchPublicCollections(keyword string) ([]ScrapedCollection, error) {
-	ws.waitForRateLimit()
+// Results are paged until a short page is returned or maxResults documents have been read.
+func (ws *WebScraper) SearchPublicCollections(ctx context.Context, keyword string) ([]ScrapedCollection, error) {
+	var collections []ScrapedCollection
+	seenURLs := make(map[string]bool)
+
+	for from := 0; ws.maxResults <= 0 || from < ws.maxResults; from += searchPageSize {
+		ws.waitForRateLimit(ctx)
+		if ctx.Err() != nil {
+			return collections, ctx.Err()
+		}
+
+		documents, err := ws.searchPage(ctx, keyword, from)
+		if err != nil {
+			// Keep what earlier pages found
+			if from > 0 {
+				return collections, nil
+			}
+			return nil, err
+		}
+
+		collections = append(collections, parseScrapedCollections(documents, seenURLs)...)
+
+		if len(documents) < searchPageSize {
+			break // Last page
+		}
+	}
+
+	return collections, nil
+}
 
+// searchPage requests one page of search results starting at offset from
+func (ws *WebScraper) searchPage(ctx context.Context, keyword string, from int) ([]map[string]interface{}, error) {
 	// Postman's internal search API endpoint
 	searchURL := "https://www.postman.com/_api/ws/proxy"
 
@@ -48,13 +96,13 @@ func (ws *WebScraper) SearchPublicCollections(keyword string) ([]ScrapedCollecti
 		"method":  "POST",
 		"path":    "/search-all",
 		"body": map[string]interface{}{
-			"from":              0,
+			"from":              from,
 			"mergeEntities":     true,
 			"nested":            false,
 			"requestOrigin":     "dropdown",
 			"nonNestedRequests": true,
 			"queryText":         keyword,
-			"size":              25, // Maximum allowed by Postman API
+			"size":              searchPageSize, // Maximum allowed by Postman API
 			"domain":            "all",
 			"filter":            map[string]interface{}{},
 			"queryIndices": []string{
@@ -72,7 +120,7 @@ func (ws *WebScraper) SearchPublicCollections(keyword string) ([]ScrapedCollecti
 		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", searchURL, strings.NewReader(string(bodyJSON)))
+	req, err := http.NewRequestWithContext(ctx, "POST", searchURL, strings.NewReader(string(bodyJSON)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -110,13 +158,20 @@ func (ws *WebScraper) SearchPublicCollections(keyword string) ([]ScrapedCollecti
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
+	documents := make([]map[string]interface{}, 0, len(searchResponse.Data))
+	for _, result := range searchResponse.Data {
+		documents = append(documents, result.Document)
+	}
+
+	return documents, nil
+}
+
+// parseScrapedCollections extracts collections from search documents, skipping URLs in seenURLs
+func parseScrapedCollections(documents []map[string]interface{}, seenURLs map[string]bool) []ScrapedCollection {
 	var collections []ScrapedCollection
-	seenURLs := make(map[string]bool)
 
 	// Parse search results
-	for _, result := range searchResponse.Data {
-		doc := result.Document
-
+	for _, doc := range documents {
 		// Check the documentType field to filter for collections
 		docType, _ := doc["documentType"].(string)
 		entityType, _ := doc["entityType"].(string)
@@ -177,7 +232,7 @@ func (ws *WebScraper) SearchPublicCollections(keyword string) ([]ScrapedCollecti
 		})
 	}
 
-	return collections, nil
+	return collections
 }
 
 // GetCollectionID extracts collection ID from URL
@@ -198,8 +253,11 @@ func (ws *WebScraper) GetCollectionID(collectionURL string) string {
 }
 
 // waitForRateLimit waits for rate limiter before making request
-func (ws *WebScraper) waitForRateLimit() {
+func (ws *WebScraper) waitForRateLimit(ctx context.Context) {
 	if ws.rateLimiter != nil {
-		<-ws.rateLimiter.C
+		select {
+		case <-ws.rateLimiter.C:
+		case <-ctx.Done():
+		}
 	}
 }
@@ -0,0 +1,98 @@
+package postman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Workspace is a workspace the API key can access
+type Workspace struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`       // personal, team, private, public or partner
+	Visibility string `json:"visibility"` // Same values; newer API responses use this field
+}
+
+// IsPublic reports whether anyone can see the workspace
+func (w Workspace) IsPublic() bool {
+	return w.Visibility == "public" || w.Type == "public"
+}
+
+// Environment is an environment in a workspace, without its variables
+type Environment struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Owner    string `json:"owner"`
+	UID      string `json:"uid"`
+	IsPublic bool   `json:"isPublic"`
+}
+
+// ListWorkspaces lists every workspace the API key can access
+func (c *Client) ListWorkspaces(ctx context.Context) ([]Workspace, error) {
+	var result struct {
+		Workspaces []Workspace `json:"workspaces"`
+	}
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/workspaces", baseURL), "list workspaces", &result); err != nil {
+		return nil, err
+	}
+	return result.Workspaces, nil
+}
+
+// GetWorkspaceEnvironments lists the environments in a workspace
+func (c *Client) GetWorkspaceEnvironments(ctx context.Context, workspaceID string) ([]Environment, error) {
+	var result struct {
+		Environments []Environment `json:"environments"`
+	}
+	endpoint := fmt.Sprintf("%s/environments?workspace=%s", baseURL, url.QueryEscape(workspaceID))
+	if err := c.getJSON(ctx, endpoint, "list environments", &result); err != nil {
+		return nil, err
+	}
+	return result.Environments, nil
+}
+
+// GetEnvironmentAsMap retrieves an environment, including its variables, as a raw map for scanning
+func (c *Client) GetEnvironmentAsMap(ctx context.Context, environmentID string) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	endpoint := fmt.Sprintf("%s/environments/%s", baseURL, url.PathEscape(environmentID))
+	if err := c.getJSON(ctx, endpoint, "get environment", &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// getJSON sends an authenticated, rate-limited GET request and decodes the response into
+// target; action names the call in errors, e.g. "list workspaces"
+func (c *Client) getJSON(ctx context.Context, endpoint, action string, target interface{}) error {
+	if c.apiKey == "" {
+		return fmt.Errorf("failed to %s: no API key provided", action)
+	}
+
+	c.waitForRateLimit(ctx)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("X-API-Key", c.apiKey)
+
+	resp, err := c.doWithRetry(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to %s (status %d): %s", action, resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
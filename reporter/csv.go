@@ -0,0 +1,150 @@
+package reporter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yourusername/postman-observer/notifier"
+	"github.com/yourusername/postman-observer/scanner"
+)
+
+// GenerateCSVReport creates a CSV report with one row per secret, for spreadsheet imports
+func (r *Reporter) GenerateCSVReport(alerts []notifier.Alert, duplicates map[string][]string) (string, error) {
+	if len(alerts) == 0 {
+		return "", nil
+	}
+
+	// Create reports directory
+	if err := os.MkdirAll(r.reportsDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create reports directory: %w", err)
+	}
+
+	filename := r.reportName("findings", "csv")
+	filepath := filepath.Join(r.reportsDir, filename)
+
+	file, err := os.Create(filepath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create CSV report: %w", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+
+	header := []string{
+		"Severity",
+		"Collection Name",
+		"Collection ID",
+		"Owner",
+		"Keyword",
+		"Secret Type",
+		"Redacted Value",
+		"Location",
+		"Position",
+		"Verified Status",
+		"Duplicate",
+	}
+	if err := writer.Write(header); err != nil {
+		return "", fmt.Errorf("failed to write CSV report: %w", err)
+	}
+
+	for _, alert := range alerts {
+		// Public collections without secrets still get a row
+		if len(alert.Secrets) == 0 {
+			row := []string{
+				"WARNING",
+				alert.Collection.Name,
+				alert.Collection.ID,
+				alert.Collection.Owner,
+				alert.Keyword,
+				"", "", "", "", "", "",
+			}
+			if err := writer.Write(neutralizeFormulas(row)); err != nil {
+				return "", fmt.Errorf("failed to write CSV report: %w", err)
+			}
+			continue
+		}
+
+		for _, secret := range alert.Secrets {
+			verification := "NOT VERIFIED"
+			if secret.Verification != nil {
+				switch secret.Verification.Status {
+				case scanner.StatusActive:
+					verification = "ACTIVE"
+				case scanner.StatusExpired:
+					verification = "EXPIRED"
+				case scanner.StatusRateLimited:
+					verification = "RATE LIMITED"
+				case scanner.StatusUnsupported:
+					verification = "NOT VERIFIABLE"
+				case scanner.StatusError:
+					verification = "ERROR"
+				case scanner.StatusTimeout:
+					verification = "TIMED OUT"
+				case scanner.StatusSkipped:
+					verification = "SKIPPED (POLICY)"
+				case scanner.StatusBudgetExceeded:
+					verification = "SKIPPED (BUDGET)"
+				case scanner.StatusReachable:
+					verification = "REACHABLE"
+				case scanner.StatusRevoked:
+					verification = "REVOKED"
+				case scanner.StatusInactive:
+					verification = "INACTIVE"
+				default:
+					verification = "INVALID"
+				}
+			}
+
+			location := secret.Location
+			if len(secret.Locations) > 0 {
+				location = strings.Join(secret.Locations, "; ")
+			}
+
+			duplicate := "no"
+			if dups, exists := duplicates[secret.RawValue]; exists && len(dups) > 1 {
+				duplicate = fmt.Sprintf("yes (%d collections)", len(dups))
+			}
+
+			row := []string{
+				strings.ToUpper(secret.EffectiveSeverity()),
+				alert.Collection.Name,
+				alert.Collection.ID,
+				alert.Collection.Owner,
+				alert.Keyword,
+				secret.Type,
+				r.exportRedaction().Redact(secret),
+				location,
+				secret.Position(),
+				verification,
+				duplicate,
+			}
+			if err := writer.Write(neutralizeFormulas(row)); err != nil {
+				return "", fmt.Errorf("failed to write CSV report: %w", err)
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", fmt.Errorf("failed to write CSV report: %w", err)
+	}
+
+	return filepath, nil
+}
+
+// formulaPrefixes are the leading characters that make spreadsheet apps evaluate a cell
+const formulaPrefixes = "=+-@\t\r"
+
+// neutralizeFormulas quotes cells a spreadsheet would run as a formula, so a collection
+// named =HYPERLINK(...) by its owner is imported as text (CSV injection)
+func neutralizeFormulas(row []string) []string {
+	for i, cell := range row {
+		if cell != "" && strings.ContainsRune(formulaPrefixes, rune(cell[0])) {
+			row[i] = "'" + cell
+		}
+	}
+	return row
+}
@@ -0,0 +1,157 @@
+package reporter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yourusername/postman-observer/notifier"
+	"github.com/yourusername/postman-observer/scanner"
+)
+
+// DeltaSecret is a secret that appeared or disappeared since the previous run
+type DeltaSecret struct {
+	CollectionID   string       `json:"collection_id"`
+	CollectionName string       `json:"collection_name"`
+	Owner          string       `json:"owner"`
+	CollectionURL  string       `json:"collection_url"`
+	Secret         SecretDetail `json:"secret"`
+}
+
+// DeltaReport lists the secrets that changed between two runs
+type DeltaReport struct {
+	ReportTime     string        `json:"report_time"`
+	PreviousReport string        `json:"previous_report"`
+	NewCount       int           `json:"new_count"`
+	ResolvedCount  int           `json:"resolved_count"`
+	New            []DeltaSecret `json:"new"`
+	Resolved       []DeltaSecret `json:"resolved"`
+
+	// NotRescanned counts previous secrets whose collection this run didn't scan (recently
+	// alerted, or no longer found because it was cleaned up or made private), which are
+	// never listed as resolved; Note says so
+	NotRescanned int    `json:"not_rescanned"`
+	Note         string `json:"note,omitempty"`
+}
+
+// LatestReport returns the path of the most recent JSON findings report of a check, or ""
+// if there is none. Call it before GenerateReport to get the previous run's report. The
+// pattern leaves out dry-run (dryrun_) and single-scan (scan_) reports.
+func (r *Reporter) LatestReport() (string, error) {
+	paths, err := filepath.Glob(filepath.Join(r.reportsDir, r.prefix+"findings_*.json"))
+	if err != nil {
+		return "", fmt.Errorf("failed to list reports: %w", err)
+	}
+
+	var latest string
+	var latestTime time.Time
+	for _, path := range paths {
+		// Re-verified reports are derived from an older run, not a run of their own
+		if strings.HasPrefix(filepath.Base(path), r.prefix+"findings_reverified_") {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(latestTime) {
+			latest, latestTime = path, info.ModTime()
+		}
+	}
+
+	return latest, nil
+}
+
+// GenerateDeltaReport compares the current alerts against a previous JSON report (by
+// collection ID + secret fingerprint, so redaction doesn't matter) and writes
+// delta_<timestamp>.json with only the secrets that newly appeared or disappeared. Only
+// collections scanned in both runs can report a secret as resolved: runs skip recently
+// alerted collections, and search no longer finds cleaned up or private ones. Previous
+// secrets of collections not scanned again are counted in NotRescanned instead.
+func (r *Reporter) GenerateDeltaReport(alerts []notifier.Alert, previousPath string) (string, error) {
+	previous, err := LoadReport(previousPath)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(r.reportsDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create reports directory: %w", err)
+	}
+
+	current := r.buildReport(alerts)
+	currentSecrets := findingSecrets(current.Findings)
+	previousSecrets := findingSecrets(previous.Findings)
+
+	rescanned := make(map[string]bool, len(current.Findings))
+	for _, finding := range current.Findings {
+		rescanned[finding.CollectionID] = true
+	}
+
+	delta := DeltaReport{
+		ReportTime:     time.Now().Format("2006-01-02 03:04:05 PM"),
+		PreviousReport: previousPath,
+		New:            []DeltaSecret{},
+		Resolved:       []DeltaSecret{},
+	}
+
+	for key, secret := range currentSecrets {
+		if _, seen := previousSecrets[key]; !seen {
+			delta.New = append(delta.New, secret)
+		}
+	}
+	for key, secret := range previousSecrets {
+		switch _, still := currentSecrets[key]; {
+		case !rescanned[secret.CollectionID]:
+			delta.NotRescanned++
+		case !still:
+			delta.Resolved = append(delta.Resolved, secret)
+		}
+	}
+	if delta.NotRescanned > 0 {
+		delta.Note = fmt.Sprintf("%d secret(s) of the previous report are in collections this run didn't scan "+
+			"(recently alerted, cleaned up or made private); they are not listed as resolved", delta.NotRescanned)
+	}
+
+	sortDeltaSecrets(delta.New)
+	sortDeltaSecrets(delta.Resolved)
+	delta.NewCount = len(delta.New)
+	delta.ResolvedCount = len(delta.Resolved)
+
+	return r.writeJSON(delta, r.reportName("delta", "json"))
+}
+
+// findingSecrets indexes the secrets of findings by collection ID + secret fingerprint.
+// Reports from before fingerprints were recorded are fingerprinted by their value, which
+// matches for reports written without redaction.
+func findingSecrets(findings []Finding) map[string]DeltaSecret {
+	secrets := make(map[string]DeltaSecret)
+	for _, finding := range findings {
+		for _, secret := range finding.Secrets {
+			fingerprint := secret.Fingerprint
+			if fingerprint == "" {
+				fingerprint = scanner.Fingerprint(secret.Value)
+			}
+			secrets[finding.CollectionID+"\x00"+fingerprint] = DeltaSecret{
+				CollectionID:   finding.CollectionID,
+				CollectionName: finding.Name,
+				Owner:          finding.Owner,
+				CollectionURL:  finding.CollectionURL,
+				Secret:         secret,
+			}
+		}
+	}
+	return secrets
+}
+
+// sortDeltaSecrets orders secrets by collection, then type, for stable output
+func sortDeltaSecrets(secrets []DeltaSecret) {
+	sort.Slice(secrets, func(i, j int) bool {
+		if secrets[i].CollectionName != secrets[j].CollectionName {
+			return secrets[i].CollectionName < secrets[j].CollectionName
+		}
+		return secrets[i].Secret.Type < secrets[j].Secret.Type
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/yourusername/postman-observer/notifier"
+	"github.com/yourusername/postman-observer/scanner"
 )
 
 // GenerateHTMLReport creates an HTML table-formatted report
@@ -36,6 +37,12 @@ func (r *Reporter) GenerateHTMLReport(alerts []notifier.Alert, duplicates map[st
 		}
 	}
 
+	dryRunNote := ""
+	if r.dryRun {
+		dryRunNote = `        <p style="color: #d29922; margin-bottom: 25px;">🧪 <strong>Dry run:</strong> search and scan only - no alerts were sent for these findings.</p>
+`
+	}
+
 	// Generate HTML
 	var html strings.Builder
 
@@ -126,6 +133,7 @@ func (r *Reporter) GenerateHTMLReport(alerts []notifier.Alert, duplicates map[st
         .badge-success { background: #27ae60; color: white; }
         .badge-danger { background: #c0392b; color: white; }
         .badge-info { background: #3498db; color: white; }
+        .badge-scope { background: #30363d; color: #c9d1d9; }
 
         .collection-name {
             font-weight: 600;
@@ -160,6 +168,29 @@ func (r *Reporter) GenerateHTMLReport(alerts []notifier.Alert, duplicates map[st
             display: block;
             margin: 4px 0;
         }
+        .secret-context {
+            font-family: 'Courier New', monospace;
+            font-size: 12px;
+            background: #0d1117;
+            padding: 6px 8px;
+            border-radius: 3px;
+            color: #8b949e;
+            white-space: pre-wrap;
+            word-break: break-all;
+            border: 1px solid #30363d;
+            margin: 4px 0;
+        }
+        .secret-claims {
+            font-size: 12px;
+            border-collapse: collapse;
+            margin: 4px 0;
+        }
+        .secret-claims th, .secret-claims td {
+            border: 1px solid #30363d;
+            padding: 2px 8px;
+            text-align: left;
+            color: #8b949e;
+        }
         .duplicate-warning {
             background: #332b00;
             border-left: 4px solid #f39c12;
@@ -202,7 +233,7 @@ func (r *Reporter) GenerateHTMLReport(alerts []notifier.Alert, duplicates map[st
     <div class="container">
         <h1>🔍 Postman Observer Security Report</h1>
         <p style="color: #8b949e; margin-bottom: 25px;">Generated: ` + time.Now().Format("Monday, January 2, 2006 at 03:04:05 PM MST") + `</p>
-
+` + dryRunNote + `
         <div class="summary">
             <div class="summary-card critical">
                 <h3>CRITICAL FINDINGS</h3>
@@ -225,7 +256,18 @@ func (r *Reporter) GenerateHTMLReport(alerts []notifier.Alert, duplicates map[st
                 <p style="font-size: 13px;">Collections analyzed</p>
             </div>
         </div>
+`)
+
+	if skipped := countVerified(alerts, scanner.StatusBudgetExceeded); skipped > 0 {
+		html.WriteString(fmt.Sprintf(`        <p style="color: #d29922; margin-bottom: 25px;">⏭️ <strong>Partial verification:</strong> the verification budget ran out, so %d secret(s) were not verified.</p>
+`, skipped))
+	}
+	if limited := countVerified(alerts, scanner.StatusRateLimited); limited > 0 {
+		html.WriteString(fmt.Sprintf(`        <p style="color: #d29922; margin-bottom: 25px;">⏸️ <strong>Verification incomplete:</strong> %d secret(s) were still rate limited when the run ended and may be active.</p>
+`, limited))
+	}
 
+	html.WriteString(`
         <table>
             <thead>
                 <tr>
@@ -316,9 +358,13 @@ func (r *Reporter) GenerateHTMLReport(alerts []notifier.Alert, duplicates map[st
 				verificationIcon := ""
 				if secret.Verification != nil {
 					if secret.Verification.IsValid {
-						verificationIcon = " ✅ <strong>ACTIVE</strong>"
+						verificationIcon = " ✅ <strong>ACTIVE</strong>" + scopeBadges(secret.Verification.Details)
+					} else if secret.Verification.SkippedByPolicy {
+						verificationIcon = " ⏭️ Not verified (policy)"
+					} else if secret.Verification.Inconclusive {
+						verificationIcon = " " + couldNotVerify(secret.Verification.Status)
 					} else {
-						verificationIcon = " ❌ Invalid"
+						verificationIcon = " " + secret.Verification.Status.Label()
 					}
 				}
 
@@ -337,11 +383,24 @@ func (r *Reporter) GenerateHTMLReport(alerts []notifier.Alert, duplicates map[st
 				// Build locations list
 				locationsHTML := ""
 				if len(secret.Locations) > 0 {
-					locationsHTML = "<small style='color: #7f8c8d;'>Locations:<br>"
+					locationsHTML = fmt.Sprintf("<details><summary><small style='color: #7f8c8d;'>Found in %d place(s)</small></summary><small style='color: #7f8c8d;'>", len(secret.Locations))
 					for _, loc := range secret.Locations {
 						locationsHTML += fmt.Sprintf("• %s<br>", gohtml.EscapeString(loc))
 					}
-					locationsHTML += "</small>"
+					locationsHTML += "</small></details>"
+				}
+				if position := secret.Position(); position != "" {
+					locationsHTML += fmt.Sprintf("<br><small style='color: #7f8c8d;'>JSON path: <code>%s</code></small>", gohtml.EscapeString(position))
+				}
+				if secret.ContextSnippet != "" {
+					locationsHTML += fmt.Sprintf(`<pre class="secret-context">%s</pre>`, gohtml.EscapeString(r.redaction.Snippet(secret)))
+				}
+				if len(secret.Metadata) > 0 {
+					locationsHTML += `<table class="secret-claims">`
+					for _, key := range metadataKeys(secret.Metadata) {
+						locationsHTML += fmt.Sprintf("<tr><th>%s</th><td>%s</td></tr>", gohtml.EscapeString(key), gohtml.EscapeString(secret.Metadata[key]))
+					}
+					locationsHTML += "</table>"
 				}
 
 				html.WriteString(fmt.Sprintf(`
@@ -354,7 +413,7 @@ func (r *Reporter) GenerateHTMLReport(alerts []notifier.Alert, duplicates map[st
 					gohtml.EscapeString(secret.Type),
 					verificationIcon,
 					occurrenceMsg,
-					gohtml.EscapeString(secret.RawValue),
+					gohtml.EscapeString(r.redaction.Redact(secret)),
 					locationsHTML,
 					duplicateMsg,
 				))
@@ -383,8 +442,7 @@ func (r *Reporter) GenerateHTMLReport(alerts []notifier.Alert, duplicates map[st
 </html>`)
 
 	// Write to file
-	timestamp := time.Now().Format("2006-01-02_03-04-05PM")
-	filename := fmt.Sprintf("findings_%s.html", timestamp)
+	filename := r.reportName("findings", "html")
 	filepath := filepath.Join(r.reportsDir, filename)
 
 	if err := os.WriteFile(filepath, []byte(html.String()), 0644); err != nil {
@@ -393,3 +451,27 @@ func (r *Reporter) GenerateHTMLReport(alerts []notifier.Alert, duplicates map[st
 
 	return filepath, nil
 }
+
+// scopeBadges renders the account and scopes a verified token carries, with the scopes
+// that grant write or admin access (or, for SendGrid, key and teammate management) in red
+func scopeBadges(details map[string]string) string {
+	if details["scopes"] == "" {
+		return ""
+	}
+
+	var badges strings.Builder
+	if login := details["login"]; login != "" {
+		badges.WriteString(fmt.Sprintf(` <span class="badge badge-info">%s</span>`, gohtml.EscapeString(login)))
+	}
+	if details["subuser"] == "true" {
+		badges.WriteString(` <span class="badge badge-info">subuser</span>`)
+	}
+	for _, scope := range scanner.ScopeBadges(details) {
+		class := "badge-scope"
+		if scope.Dangerous {
+			class = "badge-danger"
+		}
+		badges.WriteString(fmt.Sprintf(`<span class="badge %s">%s</span>`, class, gohtml.EscapeString(scope.Scope)))
+	}
+	return badges.String()
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/yourusername/postman-observer/notifier"
+	"github.com/yourusername/postman-observer/scanner"
 )
 
 // GenerateMarkdownReport creates a Markdown table-formatted report
@@ -40,6 +41,9 @@ func (r *Reporter) GenerateMarkdownReport(alerts []notifier.Alert, duplicates ma
 	// Header
 	md.WriteString("# 🔍 Postman Observer Security Report\n\n")
 	md.WriteString(fmt.Sprintf("**Generated:** %s\n\n", time.Now().Format("Monday, January 2, 2006 at 03:04:05 PM MST")))
+	if r.dryRun {
+		md.WriteString("> 🧪 **Dry run:** search and scan only - no alerts were sent for these findings.\n\n")
+	}
 
 	md.WriteString("---\n\n")
 
@@ -52,6 +56,13 @@ func (r *Reporter) GenerateMarkdownReport(alerts []notifier.Alert, duplicates ma
 	md.WriteString(fmt.Sprintf("| 🔑 **Total Secrets** | **%d** | Total credentials exposed |\n", totalSecrets))
 	md.WriteString(fmt.Sprintf("| 📦 **Total Findings** | **%d** | Collections analyzed |\n\n", len(alerts)))
 
+	if skipped := countVerified(alerts, scanner.StatusBudgetExceeded); skipped > 0 {
+		md.WriteString(fmt.Sprintf("> ⏭️ **Partial verification:** the verification budget ran out, so %d secret(s) were not verified. Raise `deep_scan.verify_budget_minutes` or `deep_scan.max_verifications` for full coverage.\n\n", skipped))
+	}
+	if limited := countVerified(alerts, scanner.StatusRateLimited); limited > 0 {
+		md.WriteString(fmt.Sprintf("> ⏸️ **Verification incomplete:** %d secret(s) were still rate limited when the run ended and may be active. Re-check them later with `-verify-report`.\n\n", limited))
+	}
+
 	md.WriteString("---\n\n")
 
 	// Detailed Findings
@@ -106,8 +117,12 @@ func (r *Reporter) GenerateMarkdownReport(alerts []notifier.Alert, duplicates ma
 				if secret.Verification != nil {
 					if secret.Verification.IsValid {
 						verification = "✅ **ACTIVE**"
+					} else if secret.Verification.SkippedByPolicy {
+						verification = "⏭️ Not verified (policy)"
+					} else if secret.Verification.Inconclusive {
+						verification = couldNotVerify(secret.Verification.Status)
 					} else {
-						verification = "❌ Invalid"
+						verification = secret.Verification.Status.Label()
 					}
 				}
 
@@ -117,7 +132,7 @@ func (r *Reporter) GenerateMarkdownReport(alerts []notifier.Alert, duplicates ma
 					duplicateNote = fmt.Sprintf(" ⚠️ **[Duplicate in %d collections]**", len(dups))
 				}
 
-				truncatedValue := secret.RawValue
+				truncatedValue := r.redaction.Redact(secret)
 				if len(truncatedValue) > 80 {
 					truncatedValue = truncatedValue[:80] + "..."
 				}
@@ -146,6 +161,15 @@ func (r *Reporter) GenerateMarkdownReport(alerts []notifier.Alert, duplicates ma
 					for _, loc := range secret.Locations {
 						md.WriteString(fmt.Sprintf("  - %s\n", escapeMarkdown(loc)))
 					}
+					if position := secret.Position(); position != "" {
+						md.WriteString(fmt.Sprintf("- JSON path: `%s`\n", position))
+					}
+					if len(secret.Metadata) > 0 {
+						md.WriteString("\n| Claim | Value |\n|-------|-------|\n")
+						for _, key := range metadataKeys(secret.Metadata) {
+							md.WriteString(fmt.Sprintf("| %s | %s |\n", escapeMarkdown(key), escapeMarkdown(secret.Metadata[key])))
+						}
+					}
 				}
 				md.WriteString("\n</details>\n\n")
 			}
@@ -156,8 +180,15 @@ func (r *Reporter) GenerateMarkdownReport(alerts []notifier.Alert, duplicates ma
 			md.WriteString("```\n")
 			for j, secret := range alert.Secrets {
 				md.WriteString(fmt.Sprintf("%d. [%s]\n", j+1, secret.Type))
-				md.WriteString(fmt.Sprintf("   Value: %s\n", secret.RawValue))
-				md.WriteString(fmt.Sprintf("   Location: %s\n\n", secret.Location))
+				md.WriteString(fmt.Sprintf("   Value: %s\n", r.redaction.Redact(secret)))
+				md.WriteString(fmt.Sprintf("   Location: %s\n", secret.Location))
+				if position := secret.Position(); position != "" {
+					md.WriteString(fmt.Sprintf("   JSON path: %s\n", position))
+				}
+				if secret.ContextSnippet != "" {
+					md.WriteString(fmt.Sprintf("   Context: %s\n", escapeCodeBlock(r.redaction.Snippet(secret))))
+				}
+				md.WriteString("\n")
 			}
 			md.WriteString("```\n")
 			md.WriteString("</details>\n\n")
@@ -213,8 +244,7 @@ func (r *Reporter) GenerateMarkdownReport(alerts []notifier.Alert, duplicates ma
 	md.WriteString("*🤖 Generated by Postman Observer*\n")
 
 	// Write to file
-	timestamp := time.Now().Format("2006-01-02_03-04-05PM")
-	filename := fmt.Sprintf("findings_%s.md", timestamp)
+	filename := r.reportName("findings", "md")
 	filepath := filepath.Join(r.reportsDir, filename)
 
 	if err := os.WriteFile(filepath, []byte(md.String()), 0644); err != nil {
@@ -224,7 +254,17 @@ func (r *Reporter) GenerateMarkdownReport(alerts []notifier.Alert, duplicates ma
 	return filepath, nil
 }
 
-// escapeMarkdown escapes special markdown characters
+// escapeCodeBlock flattens text onto one line and breaks up fences so it can't end
+// the surrounding ``` block
+func escapeCodeBlock(s string) string {
+	replacer := strings.NewReplacer(
+		"\r", "",
+		"\n", " ⏎ ",
+		"```", "`\u200b`\u200b`",
+	)
+	return replacer.Replace(s)
+}
+
 func escapeMarkdown(s string) string {
 	replacer := strings.NewReplacer(
 		"|", "\\|",
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/yourusername/postman-observer/config"
 	"github.com/yourusername/postman-observer/notifier"
 	"github.com/yourusername/postman-observer/scanner"
 )
@@ -31,17 +34,79 @@ type Finding struct {
 
 // SecretDetail represents detailed secret information
 type SecretDetail struct {
-	Type        string   `json:"type"`
-	Value       string   `json:"value"`       // Full unmasked value
-	Location    string   `json:"location"`    // Primary location (kept for backwards compatibility)
-	Locations   []string `json:"locations"`   // All locations where this secret was found
-	Occurrences int      `json:"occurrences"` // Number of times found
-	FullPath    string   `json:"full_path"`
-	Description string   `json:"description"`
-	IsVerified  bool     `json:"is_verified"`
-	IsValid     bool     `json:"is_valid"`
-	RateLimited bool     `json:"rate_limited"`
-	VerifyMsg   string   `json:"verify_message,omitempty"`
+	Type        string            `json:"type"`
+	Value       string            `json:"value"`       // Full unmasked value
+	Fingerprint string            `json:"fingerprint"` // SHA-256 of the raw value, the same under every redaction policy
+	Location    string            `json:"location"`    // Primary location (kept for backwards compatibility)
+	Locations   []string          `json:"locations"`   // All locations where this secret was found
+	Occurrences int               `json:"occurrences"` // Number of times found
+	FullPath    string            `json:"full_path"`
+	Description string            `json:"description"`
+	Severity    string            `json:"severity,omitempty"`
+	Confidence  string            `json:"confidence,omitempty"`
+	Host        string            `json:"host,omitempty"`            // Service host needed to re-verify the secret
+	Position    *SecretPosition   `json:"position,omitempty"`        // Where the secret sits in the collection JSON
+	Context     string            `json:"context_snippet,omitempty"` // Surrounding text, secret redacted
+	Metadata    map[string]string `json:"metadata,omitempty"`        // Decoded details, e.g. JWT claims
+	IsVerified  bool              `json:"is_verified"`
+	IsValid     bool              `json:"is_valid"`
+	RateLimited bool              `json:"rate_limited"`
+	VerifyMsg   string            `json:"verify_message,omitempty"`
+
+	// VerifyStatus is the verification outcome: active, invalid, expired, rate_limited,
+	// unsupported, error, timeout, skipped, budget_exceeded or reachable
+	VerifyStatus string `json:"verify_status,omitempty"`
+
+	// Inconclusive marks a check that failed (rate limited, timed out, network error): the
+	// secret may be live, unlike one that is_valid false alone reports as invalid
+	Inconclusive bool `json:"inconclusive,omitempty"`
+
+	// Placeholder marks a value that looks made up (e.g. contains SAMPLE) but is reported,
+	// at low severity, under its real type
+	Placeholder bool `json:"possible_placeholder,omitempty"`
+
+	// SkippedByPolicy marks a secret deliberately left unverified (deep_scan.verify_types)
+	SkippedByPolicy bool `json:"skipped_by_policy,omitempty"`
+	VerifyAttempts  int  `json:"verify_attempts,omitempty"` // Requests the check took, retries included
+
+	VerifyDetails map[string]string `json:"verify_details,omitempty"` // What the provider reported, e.g. GitHub scopes
+}
+
+// SecretPosition pinpoints a secret inside the collection: the JSON path of the scanned
+// field and the line/column (1-based) and byte offset of the match within it
+type SecretPosition struct {
+	JSONPath string `json:"json_path"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Offset   int    `json:"offset"`
+}
+
+// couldNotVerify is how reports show an inconclusive check, e.g. "⚠️ Could not verify (timeout)"
+func couldNotVerify(status scanner.VerificationStatus) string {
+	return fmt.Sprintf("⚠️ Could not verify (%s)", strings.ReplaceAll(string(status), "_", " "))
+}
+
+// secretPosition returns the position of a match, or nil if it wasn't recorded
+func secretPosition(secret scanner.SecretMatch) *SecretPosition {
+	if secret.JSONPath == "" {
+		return nil
+	}
+	return &SecretPosition{
+		JSONPath: secret.JSONPath,
+		Line:     secret.Line,
+		Column:   secret.Column,
+		Offset:   secret.Offset,
+	}
+}
+
+// metadataKeys returns the keys of a secret's metadata in a stable order
+func metadataKeys(metadata map[string]string) []string {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // Report represents the complete report structure
@@ -52,18 +117,104 @@ type Report struct {
 	WarningCount  int       `json:"warning_count"`
 	TotalSecrets  int       `json:"total_secrets"`
 	Findings      []Finding `json:"findings"`
+
+	// BudgetSkipped counts secrets left unverified because the run's verification budget
+	// ran out, so coverage of this report is partial
+	BudgetSkipped int `json:"budget_skipped,omitempty"`
+
+	// RateLimited counts secrets providers were still rate limiting at the end of the run,
+	// so their verification is incomplete
+	RateLimited int `json:"rate_limited,omitempty"`
+
+	// DryRun marks a report written by a -dry-run check, for which no alerts were sent
+	DryRun bool `json:"dry_run,omitempty"`
+
+	// Redaction is the policy secret values were written under; only "none" reports hold
+	// the raw values -verify-report needs
+	Redaction string `json:"redaction,omitempty"`
 }
 
 // Reporter handles report generation
 type Reporter struct {
 	reportsDir string
+	prefix     string // Prepended to report filenames, so instances can share a directory
+	redaction  scanner.RedactionPolicy
+	dryRun     bool            // Name and label reports as written by a dry run
+	singleScan bool            // Name reports as written by a single scan, with no delta
+	formats    map[string]bool // Report formats written each run (see config.ReportFormats)
 }
 
 // NewReporter creates a new reporter instance
-func NewReporter(reportsDir string) *Reporter {
+func NewReporter(cfg config.ReportsConfig) *Reporter {
+	prefix := cfg.Prefix
+	if prefix != "" {
+		prefix += "_"
+	}
+
+	// The formats were validated with the configuration
+	parsed, _ := config.ParseReportFormats(cfg.Formats)
+	formats := make(map[string]bool, len(parsed))
+	for _, format := range parsed {
+		formats[format] = true
+	}
+
 	return &Reporter{
-		reportsDir: reportsDir,
+		reportsDir: cfg.Dir,
+		prefix:     prefix,
+		redaction:  scanner.RedactNone,
+		formats:    formats,
+	}
+}
+
+// Writes reports whether the run's reports include the given format, e.g. config.ReportSARIF.
+// Single scans never write a delta report.
+func (r *Reporter) Writes(format string) bool {
+	if r.singleScan && format == config.ReportDelta {
+		return false
+	}
+	return r.formats[format]
+}
+
+// SetRedactionPolicy chooses how reports show secret values (full raw values by default)
+func (r *Reporter) SetRedactionPolicy(policy scanner.RedactionPolicy) {
+	r.redaction = policy
+}
+
+// exportRedaction is the policy for CSV and SARIF reports, which are made to be imported
+// elsewhere (spreadsheets, GitHub code scanning): at least partial redaction, whatever the
+// configured policy
+func (r *Reporter) exportRedaction() scanner.RedactionPolicy {
+	if r.redaction.AtLeastAsStrictAs(scanner.RedactPartial) {
+		return r.redaction
+	}
+	return scanner.RedactPartial
+}
+
+// SetDryRun marks the reports as written by a dry run: their filenames start with
+// "dryrun_" (so LatestReport doesn't take them for a real run's) and their headers say
+// that no alerts were sent
+func (r *Reporter) SetDryRun(enabled bool) {
+	r.dryRun = enabled
+}
+
+// SetSingleScan marks the reports as written by a single scan (-collection, -workspaces,
+// -env-scan) rather than a check: their filenames start with "scan_", so LatestReport
+// doesn't take a scan of a few collections for the previous run of a later delta report
+func (r *Reporter) SetSingleScan(enabled bool) {
+	r.singleScan = enabled
+}
+
+// reportName returns a timestamped report filename, e.g. "<prefix>_findings_<timestamp>.html",
+// or "<prefix>_dryrun_findings_<timestamp>.html" in dry-run mode
+func (r *Reporter) reportName(kind, ext string) string {
+	if r.singleScan {
+		kind = "scan_" + kind
+	}
+	if r.dryRun {
+		kind = "dryrun_" + kind
 	}
+	timestamp := time.Now().Format("2006-01-02_03-04-05PM")
+	return fmt.Sprintf("%s%s_%s.%s", r.prefix, kind, timestamp, ext)
 }
 
 // DetectDuplicateSecrets finds secrets that appear in multiple collections
@@ -100,6 +251,14 @@ func (r *Reporter) GenerateReport(alerts []notifier.Alert) (string, error) {
 		return "", fmt.Errorf("failed to create reports directory: %w", err)
 	}
 
+	report := r.buildReport(alerts)
+
+	// Generate filename with timestamp
+	return r.writeJSON(report, r.reportName("findings", "json"))
+}
+
+// buildReport converts alerts into the JSON report structure
+func (r *Reporter) buildReport(alerts []notifier.Alert) Report {
 	// Detect duplicate secrets (not used in JSON report but kept for consistency)
 	_ = DetectDuplicateSecrets(alerts)
 
@@ -108,6 +267,7 @@ func (r *Reporter) GenerateReport(alerts []notifier.Alert) (string, error) {
 		ReportTime:    time.Now().Format("2006-01-02 03:04:05 PM"),
 		TotalFindings: len(alerts),
 		Findings:      make([]Finding, 0, len(alerts)),
+		Redaction:     string(r.redaction),
 	}
 
 	totalSecrets := 0
@@ -153,20 +313,33 @@ func (r *Reporter) GenerateReport(alerts []notifier.Alert) (string, error) {
 		for _, secret := range alert.Secrets {
 			detail := SecretDetail{
 				Type:        secret.Type,
-				Value:       secret.RawValue, // Use full unmasked value
+				Value:       r.redaction.Redact(secret),
+				Fingerprint: scanner.Fingerprint(secret.RawValue),
 				Location:    secret.Location, // Primary location for backwards compatibility
 				Locations:   secret.Locations,
 				Occurrences: secret.Occurrences,
 				FullPath:    secret.FullPath,
 				Description: secret.Description,
+				Severity:    secret.Severity,
+				Confidence:  secret.Confidence,
+				Placeholder: secret.Placeholder,
+				Host:        secret.Host,
+				Position:    secretPosition(secret),
+				Context:     r.redaction.Snippet(secret),
+				Metadata:    secret.Metadata,
 			}
 
 			// Add verification details if available
 			if secret.Verification != nil {
-				detail.IsVerified = true
+				detail.IsVerified = !secret.Verification.SkippedByPolicy && secret.Verification.Status != scanner.StatusBudgetExceeded
 				detail.IsValid = secret.Verification.IsValid
 				detail.RateLimited = secret.Verification.RateLimited
+				detail.SkippedByPolicy = secret.Verification.SkippedByPolicy
+				detail.VerifyAttempts = secret.Verification.Attempts
+				detail.VerifyDetails = secret.Verification.Details
 				detail.VerifyMsg = secret.Verification.Message
+				detail.VerifyStatus = string(secret.Verification.Status)
+				detail.Inconclusive = secret.Verification.Inconclusive
 			}
 
 			finding.Secrets = append(finding.Secrets, detail)
@@ -177,10 +350,55 @@ func (r *Reporter) GenerateReport(alerts []notifier.Alert) (string, error) {
 	}
 
 	report.TotalSecrets = totalSecrets
+	report.BudgetSkipped = countVerified(alerts, scanner.StatusBudgetExceeded)
+	report.RateLimited = countVerified(alerts, scanner.StatusRateLimited)
+	report.DryRun = r.dryRun
 
-	// Generate filename with timestamp
-	timestamp := time.Now().Format("2006-01-02_03-04-05PM")
-	filename := fmt.Sprintf("findings_%s.json", timestamp)
+	return report
+}
+
+// countVerified counts the secrets whose verification ended with status, e.g. the ones
+// left unverified because the run's verification budget ran out
+func countVerified(alerts []notifier.Alert, status scanner.VerificationStatus) int {
+	count := 0
+	for _, alert := range alerts {
+		for _, secret := range alert.Secrets {
+			if secret.Verification != nil && secret.Verification.Status == status {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// LoadReport reads a previously generated JSON report
+func LoadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse report: %w", err)
+	}
+
+	return &report, nil
+}
+
+// WriteVerifiedReport writes a report whose secrets were re-verified, stamped with the current time
+func (r *Reporter) WriteVerifiedReport(report *Report) (string, error) {
+	if err := os.MkdirAll(r.reportsDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create reports directory: %w", err)
+	}
+
+	report.ReportTime = time.Now().Format("2006-01-02 03:04:05 PM")
+
+	return r.writeJSON(*report, r.reportName("findings_reverified", "json"))
+}
+
+// writeJSON writes v as indented JSON into the reports directory
+func (r *Reporter) writeJSON(v interface{}, filename string) (string, error) {
 	filepath := filepath.Join(r.reportsDir, filename)
 
 	// Write JSON report
@@ -192,7 +410,7 @@ func (r *Reporter) GenerateReport(alerts []notifier.Alert) (string, error) {
 
 	encoder := json.NewEncoder(file)
 	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(report); err != nil {
+	if err := encoder.Encode(v); err != nil {
 		return "", fmt.Errorf("failed to write report: %w", err)
 	}
 
@@ -200,24 +418,37 @@ func (r *Reporter) GenerateReport(alerts []notifier.Alert) (string, error) {
 }
 
 // ConvertSecretsToDetails converts scanner secrets to report details
-func ConvertSecretsToDetails(secrets []scanner.SecretMatch) []SecretDetail {
+func (r *Reporter) ConvertSecretsToDetails(secrets []scanner.SecretMatch) []SecretDetail {
 	details := make([]SecretDetail, 0, len(secrets))
 	for _, secret := range secrets {
 		detail := SecretDetail{
 			Type:        secret.Type,
-			Value:       secret.RawValue, // Use full unmasked value
+			Value:       r.redaction.Redact(secret),
+			Fingerprint: scanner.Fingerprint(secret.RawValue),
 			Location:    secret.Location, // Primary location for backwards compatibility
 			Locations:   secret.Locations,
 			Occurrences: secret.Occurrences,
 			FullPath:    secret.FullPath,
 			Description: secret.Description,
+			Severity:    secret.Severity,
+			Confidence:  secret.Confidence,
+			Placeholder: secret.Placeholder,
+			Host:        secret.Host,
+			Position:    secretPosition(secret),
+			Context:     r.redaction.Snippet(secret),
+			Metadata:    secret.Metadata,
 		}
 
 		if secret.Verification != nil {
-			detail.IsVerified = true
+			detail.IsVerified = !secret.Verification.SkippedByPolicy && secret.Verification.Status != scanner.StatusBudgetExceeded
 			detail.IsValid = secret.Verification.IsValid
 			detail.RateLimited = secret.Verification.RateLimited
+			detail.SkippedByPolicy = secret.Verification.SkippedByPolicy
+			detail.VerifyAttempts = secret.Verification.Attempts
+			detail.VerifyDetails = secret.Verification.Details
 			detail.VerifyMsg = secret.Verification.Message
+			detail.VerifyStatus = string(secret.Verification.Status)
+			detail.Inconclusive = secret.Verification.Inconclusive
 		}
 
 		details = append(details, detail)
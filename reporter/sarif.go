@@ -0,0 +1,234 @@
+package reporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yourusername/postman-observer/notifier"
+	"github.com/yourusername/postman-observer/scanner"
+)
+
+// SARIF 2.1.0 document structure (only the parts we emit)
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string                 `json:"id"`
+	Name                 string                 `json:"name"`
+	ShortDescription     sarifMessage           `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration     `json:"defaultConfiguration"`
+	Properties           map[string]interface{} `json:"properties"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string                 `json:"ruleId"`
+	Level               string                 `json:"level"`
+	Message             sarifMessage           `json:"message"`
+	Locations           []sarifLocation        `json:"locations"`
+	PartialFingerprints map[string]string      `json:"partialFingerprints"`
+	Properties          map[string]interface{} `json:"properties"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// GenerateSARIFReport creates a SARIF 2.1.0 report for GitHub code scanning,
+// with one result per secret
+func (r *Reporter) GenerateSARIFReport(alerts []notifier.Alert) (string, error) {
+	if len(alerts) == 0 {
+		return "", nil
+	}
+
+	// Create reports directory
+	if err := os.MkdirAll(r.reportsDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create reports directory: %w", err)
+	}
+
+	rules := make([]sarifRule, 0)
+	ruleSeen := make(map[string]bool)
+	results := make([]sarifResult, 0)
+
+	for _, alert := range alerts {
+		for _, secret := range alert.Secrets {
+			ruleID := sarifRuleID(secret.Type)
+			if !ruleSeen[ruleID] {
+				ruleSeen[ruleID] = true
+				rules = append(rules, sarifRule{
+					ID:                   ruleID,
+					Name:                 strings.ReplaceAll(secret.Type, " ", ""),
+					ShortDescription:     sarifMessage{Text: secret.Type + " exposed in a public Postman collection"},
+					DefaultConfiguration: sarifConfiguration{Level: "warning"},
+					Properties: map[string]interface{}{
+						"tags":              []string{"security", "secret"},
+						"security-severity": "8.0",
+					},
+				})
+			}
+
+			locations := secret.Locations
+			if len(locations) == 0 {
+				locations = []string{secret.Location}
+			}
+
+			var logical []sarifLogicalLocation
+			for _, loc := range locations {
+				logical = append(logical, sarifLogicalLocation{FullyQualifiedName: loc})
+			}
+
+			properties := map[string]interface{}{
+				"collectionId":   alert.Collection.ID,
+				"collectionName": alert.Collection.Name,
+				"collectionUrl":  fmt.Sprintf("https://www.postman.com/collection/%s", alert.Collection.ID),
+				"owner":          alert.Collection.Owner,
+				"keyword":        alert.Keyword,
+				"occurrences":    secret.Occurrences,
+			}
+			// The region can't point into the collection file, which is fetched rather than
+			// checked in; the position within the scanned field goes alongside instead
+			if position := secretPosition(secret); position != nil {
+				properties["position"] = position
+			}
+
+			results = append(results, sarifResult{
+				RuleID: ruleID,
+				Level:  sarifLevel(secret),
+				Message: sarifMessage{Text: fmt.Sprintf("%s (%s) found in collection '%s' at %s",
+					secret.Type, r.exportRedaction().Redact(secret), alert.Collection.Name, strings.Join(locations, ", "))},
+				Locations: []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: fmt.Sprintf("postman/collections/%s.json", alert.Collection.ID)},
+						Region:           sarifRegion{StartLine: 1},
+					},
+					LogicalLocations: logical,
+				}},
+				PartialFingerprints: map[string]string{
+					"secretLocation/v1": sarifFingerprint(alert.Collection.ID, secret),
+				},
+				Properties: properties,
+			})
+		}
+	}
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "Postman Observer",
+				InformationURI: "https://github.com/0xDTC/0xPostMan-Observer",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+
+	filename := r.reportName("findings", "sarif")
+	filepath := filepath.Join(r.reportsDir, filename)
+
+	file, err := os.Create(filepath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create SARIF report: %w", err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(log); err != nil {
+		return "", fmt.Errorf("failed to write SARIF report: %w", err)
+	}
+
+	return filepath, nil
+}
+
+// sarifFingerprint identifies a result across runs by the secret's type, collection and
+// JSON path (its location when no path was recorded). It is deliberately not derived from
+// the value: a hash of a low-entropy password published to code scanning could be brute
+// forced offline.
+func sarifFingerprint(collectionID string, secret scanner.SecretMatch) string {
+	path := secret.JSONPath
+	if path == "" {
+		path = secret.Location
+	}
+	sum := sha256.Sum256([]byte(secret.Type + "\x00" + collectionID + "\x00" + path))
+	return hex.EncodeToString(sum[:])
+}
+
+// sarifRuleID derives a stable rule ID from a secret type, e.g. "AWS Access Key" -> "aws-access-key"
+func sarifRuleID(secretType string) string {
+	var id strings.Builder
+	for _, r := range strings.ToLower(secretType) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			id.WriteRune(r)
+		case id.Len() > 0 && !strings.HasSuffix(id.String(), "-"):
+			id.WriteRune('-')
+		}
+	}
+	return strings.TrimSuffix(id.String(), "-")
+}
+
+// sarifLevel maps verification status to a SARIF level: verified active secrets are errors
+func sarifLevel(secret scanner.SecretMatch) string {
+	if secret.Verification == nil {
+		return "warning"
+	}
+	switch secret.Verification.Status {
+	case scanner.StatusActive:
+		return "error"
+	case scanner.StatusInvalid, scanner.StatusExpired, scanner.StatusRevoked:
+		return "note"
+	}
+	// Rate limited, skipped or failed checks say nothing about the secret, and an inactive
+	// one comes back with its user
+	return "warning"
+}
@@ -0,0 +1,61 @@
+package reporter
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/postman-observer/config"
+	"github.com/yourusername/postman-observer/notifier"
+	"github.com/yourusername/postman-observer/scanner"
+)
+
+// sarifFingerprints writes the SARIF report of alerts and returns each result's partial
+// fingerprints
+func sarifFingerprints(t *testing.T, r *Reporter, alerts []notifier.Alert) []map[string]string {
+	t.Helper()
+	path, err := r.GenerateSARIFReport(alerts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatal(err)
+	}
+	var fingerprints []map[string]string
+	for _, result := range log.Runs[0].Results {
+		fingerprints = append(fingerprints, result.PartialFingerprints)
+	}
+	return fingerprints
+}
+
+func TestSARIFFingerprintHidesTheValue(t *testing.T) {
+	const password = "hunter2"
+	secret := func(raw string) scanner.SecretMatch {
+		return scanner.SecretMatch{Type: "Generic Password", Value: "****", RawValue: raw, JSONPath: "variable[0].value"}
+	}
+	alert := testAlert("a")
+	alert.Secrets = []scanner.SecretMatch{secret(password)}
+
+	r := NewReporter(config.ReportsConfig{Dir: t.TempDir()})
+	r.SetRedactionPolicy(scanner.RedactFull)
+	first := sarifFingerprints(t, r, []notifier.Alert{alert})
+
+	for _, value := range first[0] {
+		if strings.Contains(value, scanner.Fingerprint(password)) {
+			t.Errorf("fingerprint %s is the hash of the raw value", value)
+		}
+	}
+
+	// The same secret found again at the same place keeps its fingerprint, so code scanning
+	// tracks it as one alert
+	alert.Secrets = []scanner.SecretMatch{secret(password)}
+	if again := sarifFingerprints(t, r, []notifier.Alert{alert}); again[0]["secretLocation/v1"] != first[0]["secretLocation/v1"] {
+		t.Errorf("fingerprint changed between runs: %v, then %v", first[0], again[0])
+	}
+}
@@ -0,0 +1,30 @@
+// Package retryafter parses the Retry-After header servers send with 429 and 503
+// responses, shared by the Postman client and the secret verifiers.
+package retryafter
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parse reads a Retry-After header, given in seconds or as an HTTP date, as the delay it
+// asks for. A date in the past asks for no delay. ok is false when the header is missing
+// or unreadable.
+func Parse(value string) (delay time.Duration, ok bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,179 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditEntry records one verification: which endpoints were contacted with which secret,
+// identified by its fingerprint only, and what came of it
+type AuditEntry struct {
+	Time        time.Time `json:"time"`
+	Provider    string    `json:"provider"`    // Policy provider name, e.g. "github", or the secret type
+	SecretType  string    `json:"secret_type"` // Type the secret was verified as
+	Fingerprint string    `json:"fingerprint"` // SHA-256 of the raw value (see Fingerprint)
+	Endpoints   []string  `json:"endpoints"`   // Every endpoint requested, without query strings or secret fragments
+	StatusCode  int       `json:"status_code,omitempty"`
+	Outcome     string    `json:"outcome"` // The verification status, or "dry_run"
+	Attempts    int       `json:"attempts,omitempty"`
+	DryRun      bool      `json:"dry_run,omitempty"`
+}
+
+// AuditHook receives an entry for every verification that reaches a provider's verifier -
+// or would have, in dry-verify mode
+type AuditHook interface {
+	Record(entry AuditEntry)
+}
+
+// AuditLog is an AuditHook appending entries to a JSONL file, created on the first entry
+type AuditLog struct {
+	path string
+
+	mu     sync.Mutex
+	file   *os.File
+	warned bool // Whether a write failure was already logged
+}
+
+// NewAuditLog returns an audit log writing to path
+func NewAuditLog(path string) *AuditLog {
+	return &AuditLog{path: path}
+}
+
+// Record appends an entry; failures are logged once rather than stopping verification
+func (a *AuditLog) Record(entry AuditEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.file == nil {
+		if err = os.MkdirAll(filepath.Dir(a.path), 0755); err == nil {
+			a.file, err = os.OpenFile(a.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		}
+	}
+	if err == nil {
+		_, err = a.file.Write(append(line, '\n'))
+	}
+	if err != nil && !a.warned {
+		a.warned = true
+		slog.Error(fmt.Sprintf("⚠️  Could not write verification audit log %s: %v", a.path, err), "audit_log", a.path, "error", err)
+	}
+}
+
+// Close closes the log file, if it was opened
+func (a *AuditLog) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.file == nil {
+		return nil
+	}
+	err := a.file.Close()
+	a.file = nil
+	return err
+}
+
+// SetAuditHook sends an audit entry for every verification to hook (nil disables auditing)
+func (v *SecretVerifier) SetAuditHook(hook AuditHook) {
+	v.audit = hook
+}
+
+// SetDryVerify disables verification requests: each verifier runs until its first request,
+// which is recorded in the audit log instead of being sent, and secrets are reported as
+// skipped
+func (v *SecretVerifier) SetDryVerify(enabled bool) {
+	v.dryVerify = enabled
+}
+
+// errDryRun stops a request in dry-verify mode once it has been recorded
+var errDryRun = errors.New("dry run - request not sent")
+
+// requestTrace collects the endpoints one verification contacts, and when a provider that
+// rate limited it said to try again
+type requestTrace struct {
+	mu        sync.Mutex
+	fragments []string // Parts of the secret that must not appear in recorded endpoints
+	endpoints []string
+	retryAt   time.Time
+	dryRun    bool // Record requests without sending them
+}
+
+// traceKey is the context key of the trace requests are recorded in
+type traceKey struct{}
+
+// secretFragments splits a raw value into the parts long enough to identify it, e.g. the
+// SID and token of "AC...:token" or the IDs of a webhook URL
+var secretFragments = regexp.MustCompile(`[A-Za-z0-9_-]{8,}`)
+
+// withRequestTrace returns a context whose requests are recorded in trace
+func withRequestTrace(ctx context.Context, trace *requestTrace) context.Context {
+	return context.WithValue(ctx, traceKey{}, trace)
+}
+
+// recordRequest notes that endpoint is about to be contacted. In dry-verify mode it
+// returns errDryRun, and the request must not be sent.
+func recordRequest(ctx context.Context, endpoint *url.URL) error {
+	trace, _ := ctx.Value(traceKey{}).(*requestTrace)
+	if trace == nil {
+		return nil
+	}
+
+	path := endpoint.EscapedPath()
+	for _, fragment := range trace.fragments {
+		path = strings.ReplaceAll(path, fragment, "[redacted]")
+	}
+	recorded := fmt.Sprintf("%s://%s%s", endpoint.Scheme, endpoint.Host, path)
+
+	trace.mu.Lock()
+	trace.endpoints = append(trace.endpoints, recorded)
+	trace.mu.Unlock()
+
+	if trace.dryRun {
+		return errDryRun
+	}
+	return nil
+}
+
+// auditEntry describes a finished verification of secret for the audit hook
+func auditEntry(secret SecretMatch, trace *requestTrace, result *VerificationResult) AuditEntry {
+	provider := verifyProviders[secret.Type]
+	if provider == "" {
+		provider = secret.Type
+	}
+	entry := AuditEntry{
+		Time:        time.Now(),
+		Provider:    provider,
+		SecretType:  secret.Type,
+		Fingerprint: Fingerprint(secret.RawValue),
+		Endpoints:   append([]string{}, trace.endpoints...),
+		StatusCode:  result.StatusCode,
+		Outcome:     string(result.Status),
+		Attempts:    result.Attempts,
+	}
+	if trace.dryRun {
+		entry.Outcome, entry.DryRun = "dry_run", true
+		entry.StatusCode, entry.Attempts = 0, 0
+	}
+	return entry
+}
+
+// dryVerifyResult is the result of a secret not verified because of dry-verify mode
+func dryVerifyResult() *VerificationResult {
+	result := &VerificationResult{
+		Status:     StatusSkipped,
+		Note:       "Dry run - not verified (deep_scan.dry_verify)",
+		VerifiedAt: time.Now(),
+	}
+	return result.finish()
+}
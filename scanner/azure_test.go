@@ -0,0 +1,59 @@
+package scanner
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestVerifyAzureSASContactsOnlyAzure(t *testing.T) {
+	const sas = "sv=2022-11-02&ss=b&srt=sco&sp=rl&se=2030-01-01T00:00:00Z&sig=Qm7vL4pR8sT1wY6zB3nC5dF0hJk9wAbC2mQ7vL4pR8s%3D"
+
+	tests := []struct {
+		name     string
+		endpoint string
+		wantNote string
+	}{
+		{"internal host", "https://internal.corp", "Endpoint internal.corp is not an Azure Storage host - not contacted"},
+		{"metadata address", "https://169.254.169.254", "Endpoint 169.254.169.254 is not an Azure Storage host - not contacted"},
+		{"private address", "https://10.0.0.5", "Endpoint 10.0.0.5 is not an Azure Storage host - not contacted"},
+		{"lookalike", "https://acct.blob.core.windows.net.attacker.example", "Endpoint acct.blob.core.windows.net.attacker.example is not an Azure Storage host - not contacted"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A hostile endpoint would claim the SAS works
+			server := newCountingServer(t, true, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`<Error><Code>AuthorizationPermissionMismatch</Code></Error>`))
+			})
+			v := newTestVerifier(t)
+			routeTo(t, v, server)
+
+			result := v.VerifySecret(context.Background(), SecretMatch{
+				Type:     "Azure SAS Connection String",
+				RawValue: "BlobEndpoint=" + tt.endpoint + "/;SharedAccessSignature=" + sas,
+			})
+			if result.Status != StatusUnsupported || result.Note != tt.wantNote {
+				t.Errorf("result = %s (%s), want unsupported (%s)", result.Status, result.Note, tt.wantNote)
+			}
+			if paths := server.requested(); len(paths) != 0 {
+				t.Errorf("endpoint was contacted: %v", paths)
+			}
+		})
+	}
+
+	t.Run("Azure host, public addresses only", func(t *testing.T) {
+		server := newCountingServer(t, true, func(w http.ResponseWriter, r *http.Request) {})
+		v := newTestVerifier(t)
+		routeTo(t, v, server)
+
+		// The request bypasses the verifier's usual transport for the address-checked one
+		v.VerifySecret(context.Background(), SecretMatch{
+			Type:     "Azure SAS Connection String",
+			RawValue: "BlobEndpoint=https://acct.blob.core.windows.net/;SharedAccessSignature=" + sas,
+		})
+		if paths := server.requested(); len(paths) != 0 {
+			t.Errorf("request went through the unchecked transport: %v", paths)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package scanner
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// base64TokenPattern finds base64-looking runs (standard or URL-safe alphabet) long enough
+// to hide a credential or a whole .env file
+var base64TokenPattern = regexp.MustCompile(`[A-Za-z0-9+/_-]{32,}={0,2}`)
+
+// maxBase64Token caps how large an encoded value is decoded and rescanned
+const maxBase64Token = 256 << 10
+
+// base64DecodedSuffix marks the location of secrets found inside decoded base64
+const base64DecodedSuffix = " (base64-decoded)"
+
+// scanBase64 decodes base64-looking values in data and reruns the scan on those that
+// decode to text. Decoded content is not decoded again, and the matches point at the
+// encoded value in data.
+func (s *SecretScanner) scanBase64(data string, at fieldPath, lines lineIndex) []SecretMatch {
+	var matches []SecretMatch
+
+	for _, loc := range base64TokenPattern.FindAllStringIndex(data, -1) {
+		token := data[loc[0]:loc[1]]
+		if len(token) > maxBase64Token {
+			continue
+		}
+
+		decoded, ok := decodeBase64Text(token)
+		if !ok {
+			continue
+		}
+
+		decodedAt := fieldPath{location: at.location + base64DecodedSuffix, segments: at.segments, decoded: true, skipped: at.skipped}
+
+		line, column := lines.position(loc[0])
+		for _, match := range s.scanData(decoded, decodedAt) {
+			match.Offset, match.Line, match.Column = loc[0], line, column
+			matches = append(matches, match)
+		}
+	}
+
+	return matches
+}
+
+// decodeBase64Text decodes a standard or URL-safe, padded or unpadded base64 value and
+// reports whether the result is printable text rather than a binary blob
+func decodeBase64Text(token string) (string, bool) {
+	encodings := []*base64.Encoding{base64.StdEncoding, base64.URLEncoding}
+	if !strings.HasSuffix(token, "=") {
+		encodings = []*base64.Encoding{base64.RawStdEncoding, base64.RawURLEncoding}
+	}
+
+	for _, encoding := range encodings {
+		decoded, err := encoding.DecodeString(token)
+		if err == nil && isPrintableText(decoded) {
+			return string(decoded), true
+		}
+	}
+	return "", false
+}
+
+// isPrintableText reports whether b is UTF-8 text made of printable characters and whitespace
+func isPrintableText(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package scanner
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// describeBasicAuth decodes a Basic Auth match into its user and password: the username
+// goes into the description and metadata, and the password becomes the raw value that is
+// redacted and deduplicated. Values that don't decode to "user:password" are left as they
+// are.
+func describeBasicAuth(match *SecretMatch) {
+	fields := strings.Fields(match.RawValue)
+	if len(fields) == 0 {
+		return
+	}
+	encoded := fields[len(fields)-1] // Without the "Basic" scheme
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		decoded, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	}
+	if err != nil || !utf8.Valid(decoded) {
+		return
+	}
+	username, password, ok := strings.Cut(string(decoded), ":")
+	if !ok || password == "" {
+		return
+	}
+
+	match.RawValue = password
+	match.Value = redactPartial(password)
+	if match.Metadata == nil {
+		match.Metadata = make(map[string]string)
+	}
+	match.Metadata["username"] = username
+	match.Description = fmt.Sprintf("Basic auth for user '%s'", username)
+}
+
+// attachBasicAuthHosts names the host a request sends its decoded Basic Auth credentials
+// to, when its URL has a literal host rather than a {{variable}}
+func attachBasicAuthHosts(matches []SecretMatch, request map[string]interface{}) {
+	host := requestHost(request)
+	if host == "" {
+		return
+	}
+
+	for i := range matches {
+		if matches[i].Type == "Basic Auth" && matches[i].Metadata["username"] != "" && matches[i].Host == "" {
+			matches[i].Host = host
+			matches[i].Description = fmt.Sprintf("%s against %s", matches[i].Description, host)
+		}
+	}
+}
+
+// requestHost returns the host of a request's URL, given as a string or as Postman's URL
+// object, or "" when it is missing or templated
+func requestHost(request map[string]interface{}) string {
+	raw, _ := request["url"].(string)
+	if urlMap, ok := request["url"].(map[string]interface{}); ok {
+		raw, _ = urlMap["raw"].(string)
+	}
+	if raw == "" {
+		return ""
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw // Postman accepts URLs without a scheme
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || strings.Contains(parsed.Hostname(), "{{") {
+		return ""
+	}
+	return strings.ToLower(parsed.Hostname())
+}
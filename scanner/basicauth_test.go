@@ -0,0 +1,83 @@
+package scanner
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestDescribeBasicAuth(t *testing.T) {
+	encode := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name         string
+		raw          string
+		wantRaw      string
+		wantUsername string
+	}{
+		{"valid", "Basic " + encode("deploy:s3cr3t-P4ss"), "s3cr3t-P4ss", "deploy"},
+		{"without scheme", encode("deploy:s3cr3t-P4ss"), "s3cr3t-P4ss", "deploy"},
+		{"unpadded", "Basic " + base64.RawStdEncoding.EncodeToString([]byte("ci:tok3n")), "tok3n", "ci"},
+		{"password with colons", "Basic " + encode("admin:a:b:c"), "a:b:c", "admin"},
+		{"no colon", "Basic " + encode("justatoken"), "", ""},
+		{"empty password", "Basic " + encode("admin:"), "", ""},
+		{"not base64", "Basic not-base64!!", "", ""},
+		{"not UTF-8", "Basic " + encode("user:\xff\xfe"), "", ""},
+		{"empty", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := SecretMatch{Type: "Basic Auth", RawValue: tt.raw, Value: "unchanged", Description: "Basic Authentication"}
+			describeBasicAuth(&match)
+
+			if tt.wantRaw == "" {
+				// Left as it is
+				if match.RawValue != tt.raw || match.Value != "unchanged" || match.Metadata["username"] != "" {
+					t.Errorf("match changed to %+v, want it left alone", match)
+				}
+				return
+			}
+			if match.RawValue != tt.wantRaw {
+				t.Errorf("raw value = %q, want the password %q", match.RawValue, tt.wantRaw)
+			}
+			if match.Metadata["username"] != tt.wantUsername {
+				t.Errorf("username = %q, want %q", match.Metadata["username"], tt.wantUsername)
+			}
+			if want := "Basic auth for user '" + tt.wantUsername + "'"; match.Description != want {
+				t.Errorf("description = %q, want %q", match.Description, want)
+			}
+			if match.Value == tt.wantRaw || match.Value == "unchanged" {
+				t.Errorf("value = %q, want the password redacted", match.Value)
+			}
+		})
+	}
+}
+
+func TestAttachBasicAuthHosts(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      interface{}
+		wantHost string
+	}{
+		{"string URL", "https://API.example.com/v1/users", "api.example.com"},
+		{"URL object", map[string]interface{}{"raw": "https://api.example.com:8443/v1"}, "api.example.com"},
+		{"without scheme", "api.example.com/v1", "api.example.com"},
+		{"templated host", "https://{{baseUrl}}/v1", ""},
+		{"missing", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := []SecretMatch{
+				{Type: "Basic Auth", Metadata: map[string]string{"username": "deploy"}, Description: "Basic auth for user 'deploy'"},
+				{Type: "Basic Auth", Description: "undecoded"},
+			}
+			attachBasicAuthHosts(matches, map[string]interface{}{"url": tt.url})
+
+			if matches[0].Host != tt.wantHost {
+				t.Errorf("host = %q, want %q", matches[0].Host, tt.wantHost)
+			}
+			if matches[1].Host != "" {
+				t.Errorf("undecoded match got host %q", matches[1].Host)
+			}
+		})
+	}
+}
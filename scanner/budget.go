@@ -0,0 +1,102 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// runBudget caps how much verification one run may do: its wall time and its number of
+// verifications. Secrets reached after either runs out get a budget_exceeded result
+// instead of holding up the run.
+type runBudget struct {
+	maxTime          time.Duration // 0 for no time limit
+	maxVerifications int           // 0 for no limit on verifications
+
+	mu        sync.Mutex
+	started   time.Time
+	used      int  // Verifications sent to providers this run
+	exhausted bool // Whether running out was already logged this run
+}
+
+// SetRunBudget limits each run (see StartRun) to maxTime of verification and
+// maxVerifications secrets sent to providers; 0 lifts either limit. Cached results and
+// policy skips don't count.
+func (v *SecretVerifier) SetRunBudget(maxTime time.Duration, maxVerifications int) {
+	v.budget.mu.Lock()
+	defer v.budget.mu.Unlock()
+	v.budget.maxTime = maxTime
+	v.budget.maxVerifications = maxVerifications
+}
+
+// StartRun resets the run budget; call it at the start of every check
+func (v *SecretVerifier) StartRun() {
+	v.budget.mu.Lock()
+	defer v.budget.mu.Unlock()
+	v.budget.started = time.Now()
+	v.budget.used = 0
+	v.budget.exhausted = false
+}
+
+// outsideBudgetKey marks a context whose verifications ignore the run budget
+type outsideBudgetKey struct{}
+
+// OutsideRunBudget returns a context whose verifications neither count against the run
+// budget nor are cut off by it. Retries of rate-limited secrets use it: those secrets
+// already had their turn, and a budget spent in the meantime shouldn't hide that they
+// are still unverified.
+func OutsideRunBudget(ctx context.Context) context.Context {
+	return context.WithValue(ctx, outsideBudgetKey{}, true)
+}
+
+// outsideBudget reports whether ctx came from OutsideRunBudget
+func outsideBudget(ctx context.Context) bool {
+	outside, _ := ctx.Value(outsideBudgetKey{}).(bool)
+	return outside
+}
+
+// take claims one verification from the budget, reporting false once it has run out
+func (b *runBudget) take() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var reason string
+	switch {
+	case b.maxTime > 0 && time.Since(b.started) >= b.maxTime:
+		reason = fmt.Sprintf("time limit of %s", b.maxTime)
+	case b.maxVerifications > 0 && b.used >= b.maxVerifications:
+		reason = fmt.Sprintf("limit of %d verification(s)", b.maxVerifications)
+	default:
+		b.used++
+		return true
+	}
+
+	if !b.exhausted {
+		b.exhausted = true
+		log.Printf("   🚨 VERIFICATION BUDGET EXHAUSTED (%s) - remaining secrets are reported unverified this run", reason)
+	}
+	return false
+}
+
+// bound limits ctx to the end of the run's time budget, so in-flight verifications stop
+// when it runs out
+func (b *runBudget) bound(ctx context.Context) (context.Context, context.CancelFunc) {
+	if outsideBudget(ctx) {
+		return context.WithCancel(ctx)
+	}
+	b.mu.Lock()
+	maxTime, started := b.maxTime, b.started
+	b.mu.Unlock()
+	if maxTime <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithDeadline(ctx, started.Add(maxTime))
+}
+
+// budgetResult is the result of a secret left unverified because the run budget ran out
+func budgetResult() *VerificationResult {
+	result := &VerificationResult{Status: StatusBudgetExceeded, VerifiedAt: time.Now()}
+	return result.finish()
+}
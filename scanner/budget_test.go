@@ -0,0 +1,121 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// slowHandler answers after delay, or gives up when the client does
+func slowHandler(delay time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+		}
+	}
+}
+
+func TestRunBudget(t *testing.T) {
+	tests := []struct {
+		name             string
+		delay            time.Duration
+		maxTime          time.Duration
+		maxVerifications int
+		secrets          int
+		wantStatuses     []VerificationStatus
+		wantRequests     int
+		wantUnder        time.Duration
+	}{
+		{
+			name:         "unlimited",
+			secrets:      3,
+			wantStatuses: []VerificationStatus{StatusActive, StatusActive, StatusActive},
+			wantRequests: 3,
+		},
+		{
+			name:             "verification limit",
+			maxVerifications: 2,
+			secrets:          4,
+			wantStatuses:     []VerificationStatus{StatusActive, StatusActive, StatusBudgetExceeded, StatusBudgetExceeded},
+			wantRequests:     2,
+		},
+		{
+			name:         "slow server cut off by the time limit",
+			delay:        5 * time.Second,
+			maxTime:      150 * time.Millisecond,
+			secrets:      3,
+			wantStatuses: []VerificationStatus{StatusTimeout, StatusBudgetExceeded, StatusBudgetExceeded},
+			wantRequests: 1,
+			wantUnder:    2 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newCountingServer(t, false, slowHandler(tt.delay))
+			v := newInternalTokenVerifier(t, server)
+			v.SetRetry(1, time.Millisecond)
+			v.SetRunBudget(tt.maxTime, tt.maxVerifications)
+			v.StartRun()
+
+			started := time.Now()
+			for i := 0; i < tt.secrets; i++ {
+				secret := SecretMatch{Type: "Internal Token", RawValue: fmt.Sprintf("tok-%d", i)}
+				result := v.VerifySecret(context.Background(), secret)
+				if result.Status != tt.wantStatuses[i] {
+					t.Errorf("secret %d: status = %s (%s), want %s", i, result.Status, result.Note, tt.wantStatuses[i])
+				}
+				if result.Status == StatusBudgetExceeded && (!result.Inconclusive || result.Attempts != 0) {
+					t.Errorf("secret %d: budget_exceeded result inconclusive = %v after %d attempt(s), want inconclusive without requests",
+						i, result.Inconclusive, result.Attempts)
+				}
+			}
+			if elapsed := time.Since(started); tt.wantUnder > 0 && elapsed > tt.wantUnder {
+				t.Errorf("took %s: the slow check wasn't cut off at the time limit", elapsed)
+			}
+			if got := len(server.requested()); got != tt.wantRequests {
+				t.Errorf("%d request(s) sent, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestStartRunResetsBudget(t *testing.T) {
+	server := newCountingServer(t, false, slowHandler(0))
+	v := newInternalTokenVerifier(t, server)
+	v.SetRunBudget(0, 1)
+
+	for run := 0; run < 2; run++ {
+		v.StartRun()
+		for i, want := range []VerificationStatus{StatusActive, StatusBudgetExceeded} {
+			secret := SecretMatch{Type: "Internal Token", RawValue: fmt.Sprintf("run-%d-tok-%d", run, i)}
+			if result := v.VerifySecret(context.Background(), secret); result.Status != want {
+				t.Errorf("run %d, secret %d: status = %s, want %s", run, i, result.Status, want)
+			}
+		}
+	}
+	if got := len(server.requested()); got != 2 {
+		t.Errorf("%d request(s) sent, want one per run", got)
+	}
+}
+
+func TestVerifySecretStopsWithContext(t *testing.T) {
+	server := newCountingServer(t, false, slowHandler(5*time.Second))
+	v := newInternalTokenVerifier(t, server)
+	v.SetRetry(1, time.Millisecond)
+	v.StartRun()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	started := time.Now()
+	result := v.VerifySecret(ctx, SecretMatch{Type: "Internal Token", RawValue: "tok-slow"})
+	if elapsed := time.Since(started); elapsed > 2*time.Second {
+		t.Errorf("took %s: cancelling the context didn't abort the request", elapsed)
+	}
+	if result.IsValid || !result.Inconclusive {
+		t.Errorf("status = %s (%s), want an inconclusive result", result.Status, result.Note)
+	}
+}
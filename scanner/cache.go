@@ -0,0 +1,152 @@
+package scanner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// defaultVerificationCacheTTL is how long a verification result is reused for the same
+// secret, so long-running instances eventually re-check keys that were rotated or revoked
+const defaultVerificationCacheTTL = 24 * time.Hour
+
+// cachedResult is a verification result as persisted in the cache file
+type cachedResult struct {
+	Status     VerificationStatus `json:"status,omitempty"`
+	Note       string             `json:"note,omitempty"`
+	IsValid    bool               `json:"is_valid"`
+	Message    string             `json:"message"`
+	StatusCode int                `json:"status_code"`
+	VerifiedAt time.Time          `json:"verified_at"`
+	ExpiresAt  *time.Time         `json:"expires_at,omitempty"`
+
+	Details  map[string]string `json:"details,omitempty"`
+	Severity string            `json:"severity,omitempty"`
+}
+
+// cacheKey identifies a secret in the verification cache: the SHA-256 of its type, host
+// and raw value, so the cache file holds no secret values
+func cacheKey(secret SecretMatch) string {
+	return Fingerprint(secret.Type + "\x00" + secret.Host + "\x00" + secret.RawValue)
+}
+
+// fresh reports whether a cached result may still be reused: it is younger than the TTL
+// and the secret hasn't expired since
+func (v *SecretVerifier) fresh(result *VerificationResult) bool {
+	if time.Since(result.VerifiedAt) >= v.cacheTTL {
+		return false
+	}
+	return result.ExpiresAt.IsZero() || time.Now().Before(result.ExpiresAt)
+}
+
+// SetCacheTTL sets how long verification results are reused (default 24h); 0 re-checks
+// every secret, though results are still recorded
+func (v *SecretVerifier) SetCacheTTL(ttl time.Duration) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.cacheTTL = ttl
+}
+
+// SetCacheFile persists the verification cache to path, loading any results already
+// saved there; a missing file starts an empty cache
+func (v *SecretVerifier) SetCacheFile(path string) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.cacheFile = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read verification cache: %w", err)
+	}
+
+	var entries map[string]cachedResult
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("failed to parse verification cache %s: %w", path, err)
+	}
+	for key, entry := range entries {
+		if entry.StatusCode == 0 {
+			continue // Cached by older versions, which also kept results of local checks
+		}
+		result := &VerificationResult{
+			Status:     entry.Status,
+			Note:       entry.Note,
+			StatusCode: entry.StatusCode,
+			VerifiedAt: entry.VerifiedAt,
+			Details:    entry.Details,
+			Severity:   entry.Severity,
+		}
+		if entry.ExpiresAt != nil {
+			result.ExpiresAt = *entry.ExpiresAt
+		}
+		// Caches written before results had a status only know whether the secret was active
+		if result.Status == "" {
+			result.Status = StatusInvalid
+			if entry.IsValid {
+				result.Status = StatusActive
+			}
+		}
+		result.finish()
+		if entry.Status == "" {
+			result.Message = entry.Message
+		}
+		v.cache[key] = result
+	}
+	return nil
+}
+
+// SaveCache writes the verification cache to its file, dropping expired results (unless
+// the TTL is 0, which only skips lookups). It does nothing without a cache file.
+func (v *SecretVerifier) SaveCache() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.cacheFile == "" {
+		return nil
+	}
+
+	entries := make(map[string]cachedResult, len(v.cache))
+	for key, result := range v.cache {
+		if v.cacheTTL > 0 && !v.fresh(result) {
+			delete(v.cache, key)
+			continue
+		}
+		entry := cachedResult{
+			Status:     result.Status,
+			Note:       result.Note,
+			IsValid:    result.IsValid,
+			Message:    result.Message,
+			StatusCode: result.StatusCode,
+			VerifiedAt: result.VerifiedAt,
+			Details:    result.Details,
+			Severity:   result.Severity,
+		}
+		if !result.ExpiresAt.IsZero() {
+			expiresAt := result.ExpiresAt
+			entry.ExpiresAt = &expiresAt
+		}
+		entries[key] = entry
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode verification cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(v.cacheFile), 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	// Write then rename, so a crash mid-write can't leave a truncated cache
+	tmp := v.cacheFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write verification cache: %w", err)
+	}
+	if err := os.Rename(tmp, v.cacheFile); err != nil {
+		return fmt.Errorf("failed to write verification cache: %w", err)
+	}
+	return nil
+}
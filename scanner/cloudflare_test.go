@@ -0,0 +1,221 @@
+package scanner
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+const (
+	cloudflareToken = "Yq3vL8pR2sT6wZ9bC4nF7hJ1kM5xD0gA-eU_iOtW"
+	cloudflareKey   = "1e4f7a0c3b6d9e2f5a8c1b4d7e0f3a6c9b2d5"
+)
+
+// cloudflareRequest builds a collection with one request to url sending the given headers
+func cloudflareRequest(url string, headers map[string]string) map[string]interface{} {
+	var header []interface{}
+	for key, value := range headers {
+		header = append(header, map[string]interface{}{"key": key, "value": value})
+	}
+	return map[string]interface{}{"item": []interface{}{map[string]interface{}{
+		"name":    "Request",
+		"request": map[string]interface{}{"method": "GET", "url": url, "header": header},
+	}}}
+}
+
+func TestDetectCloudflareCredentials(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		headers  map[string]string
+		wantType string
+		wantRaw  string
+		wantDesc string
+	}{
+		{
+			name:     "API token",
+			url:      "https://api.cloudflare.com/client/v4/zones",
+			headers:  map[string]string{"Authorization": "Bearer " + cloudflareToken},
+			wantType: "Cloudflare API Token",
+			wantRaw:  cloudflareToken,
+			wantDesc: "Cloudflare API Token",
+		},
+		{
+			name:    "40-char bearer token elsewhere",
+			url:     "https://api.example.com/v1/orders",
+			headers: map[string]string{"Authorization": "Bearer " + cloudflareToken},
+		},
+		{
+			name:     "Global API key with email",
+			url:      "https://api.cloudflare.com/client/v4/zones",
+			headers:  map[string]string{"X-Auth-Email": "ops@example.com", "X-Auth-Key": cloudflareKey},
+			wantType: "Cloudflare Global API Key",
+			wantRaw:  "ops@example.com:" + cloudflareKey,
+			wantDesc: "Cloudflare Global API Key for ops@example.com",
+		},
+		{
+			name:     "Global API key alone",
+			url:      "https://api.cloudflare.com/client/v4/zones",
+			headers:  map[string]string{"X-Auth-Key": cloudflareKey},
+			wantType: "Cloudflare Global API Key",
+			wantRaw:  cloudflareKey,
+			wantDesc: "Cloudflare Global API Key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, _ := NewSecretScanner().ScanCollection(cloudflareRequest(tt.url, tt.headers))
+			var found []SecretMatch
+			for _, match := range matches {
+				if match.Type == "Cloudflare API Token" || match.Type == "Cloudflare Global API Key" {
+					found = append(found, match)
+				}
+			}
+			if tt.wantType == "" {
+				if len(found) != 0 {
+					t.Errorf("found %+v, want no Cloudflare credentials", found)
+				}
+				return
+			}
+			if len(found) != 1 {
+				t.Fatalf("found %d Cloudflare credentials, want 1", len(found))
+			}
+			if found[0].Type != tt.wantType || found[0].RawValue != tt.wantRaw || found[0].Description != tt.wantDesc {
+				t.Errorf("found %s %q (%s), want %s %q (%s)", found[0].Type, found[0].RawValue, found[0].Description, tt.wantType, tt.wantRaw, tt.wantDesc)
+			}
+		})
+	}
+}
+
+func TestVerifyCloudflare(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantStatus  VerificationStatus
+		wantNote    string
+		wantDetails map[string]string
+	}{
+		{
+			name:       "active",
+			status:     200,
+			body:       `{"success": true, "result": {"id": "ed17574386854bf78a67040be0a770b0", "status": "active"}, "errors": [], "messages": [{"code": 10000, "message": "This API Token is valid and active"}]}`,
+			wantStatus: StatusActive,
+			wantNote:   "Cloudflare API token is valid",
+		},
+		{
+			name:        "active with expiry",
+			status:      200,
+			body:        `{"success": true, "result": {"id": "ed17574386854bf78a67040be0a770b0", "status": "active", "expires_on": "2026-12-31T23:59:59Z"}}`,
+			wantStatus:  StatusActive,
+			wantNote:    "Cloudflare API token is valid (expires 2026-12-31T23:59:59Z)",
+			wantDetails: map[string]string{"expires_at": "2026-12-31T23:59:59Z"},
+		},
+		{
+			name:       "disabled",
+			status:     200,
+			body:       `{"success": true, "result": {"id": "ed17574386854bf78a67040be0a770b0", "status": "disabled"}}`,
+			wantStatus: StatusInvalid,
+			wantNote:   "Token is disabled",
+		},
+		{
+			name:       "invalid",
+			status:     401,
+			body:       `{"success": false, "errors": [{"code": 1000, "message": "Invalid API Token"}]}`,
+			wantStatus: StatusInvalid,
+			wantNote:   "Token not valid",
+		},
+		{
+			name:       "rate limited",
+			status:     429,
+			wantStatus: StatusRateLimited,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newCountingServer(t, true, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/client/v4/user/tokens/verify" || r.Header.Get("Authorization") != "Bearer "+cloudflareToken {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+			v := newTestVerifier(t)
+			v.SetRetry(1, 0)
+			routeTo(t, v, server)
+
+			result := v.VerifySecret(context.Background(), SecretMatch{Type: "Cloudflare API Token", RawValue: cloudflareToken})
+			if result.Status != tt.wantStatus {
+				t.Fatalf("status = %s (%s), want %s", result.Status, result.Note, tt.wantStatus)
+			}
+			if result.Note != tt.wantNote {
+				t.Errorf("note = %q, want %q", result.Note, tt.wantNote)
+			}
+			for key, want := range tt.wantDetails {
+				if result.Details[key] != want {
+					t.Errorf("details[%s] = %q, want %q", key, result.Details[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyCloudflareGlobalKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		credential string
+		status     int
+		wantStatus VerificationStatus
+		wantNote   string
+		wantProbe  bool
+	}{
+		{
+			name:       "email unknown",
+			credential: cloudflareKey,
+			wantStatus: StatusUnsupported,
+			wantNote:   "Valid format, account email unknown",
+		},
+		{
+			name:       "active",
+			credential: "ops@example.com:" + cloudflareKey,
+			status:     200,
+			wantStatus: StatusActive,
+			wantNote:   "Cloudflare Global API key for ops@example.com (full account access)",
+			wantProbe:  true,
+		},
+		{
+			name:       "invalid",
+			credential: "ops@example.com:" + cloudflareKey,
+			status:     403,
+			wantStatus: StatusInvalid,
+			wantNote:   "Key not valid",
+			wantProbe:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newCountingServer(t, true, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/client/v4/user" || r.Header.Get("X-Auth-Email") != "ops@example.com" || r.Header.Get("X-Auth-Key") != cloudflareKey {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(tt.status)
+			})
+			v := newTestVerifier(t)
+			v.SetRetry(1, 0)
+			routeTo(t, v, server)
+
+			result := v.VerifySecret(context.Background(), SecretMatch{Type: "Cloudflare Global API Key", RawValue: tt.credential})
+			if result.Status != tt.wantStatus {
+				t.Fatalf("status = %s (%s), want %s", result.Status, result.Note, tt.wantStatus)
+			}
+			if result.Note != tt.wantNote {
+				t.Errorf("note = %q, want %q", result.Note, tt.wantNote)
+			}
+			if probed := len(server.requested()) > 0; probed != tt.wantProbe {
+				t.Errorf("Cloudflare probed = %t, want %t", probed, tt.wantProbe)
+			}
+		})
+	}
+}
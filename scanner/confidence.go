@@ -0,0 +1,77 @@
+package scanner
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Confidence levels: how likely a match is a real secret rather than a look-alike
+const (
+	ConfidenceLow    = "low"    // Generic key=value or format-only patterns
+	ConfidenceMedium = "medium" // Specific formats that still collide with other data
+	ConfidenceHigh   = "high"   // Vendor-prefixed tokens and structural detections
+)
+
+var confidenceRanks = map[string]int{
+	ConfidenceLow:    1,
+	ConfidenceMedium: 2,
+	ConfidenceHigh:   3,
+}
+
+// Confidence of matches not produced by a regex pattern; unlisted types are high
+var detectorConfidences = map[string]string{
+	"URL Embedded Credentials": ConfidenceMedium,
+	"Firebase Database URL":    ConfidenceMedium,
+}
+
+// ParseConfidence validates a confidence level; an empty level means low (report everything)
+func ParseConfidence(level string) (string, error) {
+	level = strings.ToLower(strings.TrimSpace(level))
+	if level == "" {
+		return ConfidenceLow, nil
+	}
+	if _, ok := confidenceRanks[level]; !ok {
+		return "", fmt.Errorf("unknown confidence %q (use low, medium or high)", level)
+	}
+	return level, nil
+}
+
+// SetMinConfidence drops matches below the given confidence level (low keeps everything)
+func (s *SecretScanner) SetMinConfidence(level string) {
+	if _, ok := confidenceRanks[level]; ok {
+		s.minConfidence = level
+	}
+}
+
+// matchConfidence returns a match's confidence, falling back to its detector's for matches
+// not produced by a regex pattern
+func matchConfidence(match SecretMatch) string {
+	if match.Confidence != "" {
+		return match.Confidence
+	}
+	if confidence, ok := detectorConfidences[match.Type]; ok {
+		return confidence
+	}
+	return ConfidenceHigh
+}
+
+// filterConfidence fills in the confidence of detector matches and drops matches below
+// the scanner's minimum
+func (s *SecretScanner) filterConfidence(matches []SecretMatch) []SecretMatch {
+	filtered := matches[:0]
+	dropped := 0
+	for _, match := range matches {
+		match.Confidence = matchConfidence(match)
+		if confidenceRanks[match.Confidence] < confidenceRanks[s.minConfidence] {
+			dropped++
+			continue
+		}
+		filtered = append(filtered, match)
+	}
+
+	if dropped > 0 {
+		log.Printf("   🧩 Skipped %d match(es) below %s confidence", dropped, s.minConfidence)
+	}
+	return filtered
+}
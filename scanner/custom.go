@@ -0,0 +1,349 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// secretPlaceholder marks where a custom verifier's request carries the secret
+const secretPlaceholder = "{{secret}}"
+
+// CustomVerifier verifies a secret type the built-in verifiers don't know, e.g. one from
+// pattern_files, against an endpoint of the user's own such as an internal token
+// introspection endpoint. The secret is only ever put into this request.
+type CustomVerifier struct {
+	Method  string            // Default GET, or POST when there is a body
+	URL     string            // {{secret}} is replaced by the URL-escaped secret
+	Headers map[string]string // {{secret}} is replaced by the secret
+	Body    string            // {{secret}} is escaped for the Content-Type header: form, JSON or as is
+
+	ActiveStatus  []int             // Status codes of an active secret (default 200)
+	InvalidStatus []int             // Status codes of an invalid one (default 401 and 403)
+	ActiveField   string            // JSON path that must be true in an active answer, e.g. "active"
+	Details       map[string]string // Detail name -> JSON path of the value to report, e.g. "user.name"
+}
+
+// NewCustomVerifier checks a custom verifier's request template and rules and fills in
+// their defaults
+func NewCustomVerifier(spec CustomVerifier) (*CustomVerifier, error) {
+	verifier := spec
+	verifier.Method = strings.ToUpper(strings.TrimSpace(spec.Method))
+	if verifier.Method == "" {
+		verifier.Method = http.MethodGet
+		if spec.Body != "" {
+			verifier.Method = http.MethodPost
+		}
+	}
+	if len(verifier.ActiveStatus) == 0 {
+		verifier.ActiveStatus = []int{http.StatusOK}
+	}
+	if len(verifier.InvalidStatus) == 0 {
+		verifier.InvalidStatus = []int{http.StatusUnauthorized, http.StatusForbidden}
+	}
+
+	if strings.Contains(templateHost(spec.URL), secretPlaceholder) {
+		return nil, fmt.Errorf("%s must not be part of the url's host", secretPlaceholder)
+	}
+	endpoint, err := url.Parse(strings.ReplaceAll(spec.URL, secretPlaceholder, "secret"))
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("url %q must be an http:// or https:// URL", spec.URL)
+	}
+	if _, err := http.NewRequest(verifier.Method, endpoint.String(), nil); err != nil {
+		return nil, fmt.Errorf("invalid method %q", spec.Method)
+	}
+
+	carried := strings.Contains(spec.URL, secretPlaceholder) || strings.Contains(spec.Body, secretPlaceholder)
+	for _, value := range spec.Headers {
+		carried = carried || strings.Contains(value, secretPlaceholder)
+	}
+	if !carried {
+		return nil, fmt.Errorf("%s appears in neither the url, the headers nor the body", secretPlaceholder)
+	}
+
+	for _, status := range append(verifier.ActiveStatus, verifier.InvalidStatus...) {
+		if status < 100 || status > 599 {
+			return nil, fmt.Errorf("invalid status code %d", status)
+		}
+	}
+	for _, active := range verifier.ActiveStatus {
+		for _, invalid := range verifier.InvalidStatus {
+			if active == invalid {
+				return nil, fmt.Errorf("status %d is both active and invalid", active)
+			}
+		}
+	}
+
+	if verifier.ActiveField != "" {
+		if _, err := parseJSONPath(verifier.ActiveField); err != nil {
+			return nil, fmt.Errorf("invalid active_field: %w", err)
+		}
+	}
+	for name, path := range verifier.Details {
+		if _, err := parseJSONPath(path); err != nil {
+			return nil, fmt.Errorf("invalid details path for %q: %w", name, err)
+		}
+	}
+	return &verifier, nil
+}
+
+// SetCustomVerifier verifies secrets of the given type with a custom verifier (see
+// NewCustomVerifier), in place of any built-in verifier for the type
+func (v *SecretVerifier) SetCustomVerifier(secretType string, verifier *CustomVerifier) {
+	if v.custom == nil {
+		v.custom = make(map[string]*CustomVerifier)
+	}
+	v.custom[secretType] = verifier
+}
+
+// verifyCustom sends a secret to its custom verifier's endpoint and reads the answer by
+// the verifier's status code and JSON rules. Notes never quote the response, which may
+// echo the secret.
+func (v *SecretVerifier) verifyCustom(ctx context.Context, custom *CustomVerifier, secret string) *VerificationResult {
+	secret = strings.TrimSpace(secret)
+
+	var body io.Reader
+	if custom.Body != "" {
+		body = strings.NewReader(strings.ReplaceAll(custom.Body, secretPlaceholder, escapeForBody(secret, custom.Headers)))
+	}
+	endpoint := strings.ReplaceAll(custom.URL, secretPlaceholder, url.QueryEscape(secret))
+	req, err := http.NewRequestWithContext(ctx, custom.Method, endpoint, body)
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+	for name, value := range custom.Headers {
+		req.Header.Set(name, strings.ReplaceAll(value, secretPlaceholder, secret))
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+	host := req.URL.Hostname()
+
+	switch {
+	case containsStatus(custom.InvalidStatus, resp.StatusCode):
+		result.Status, result.Note = StatusInvalid, fmt.Sprintf("Rejected by %s (status %d)", host, resp.StatusCode)
+		return result
+	case resp.StatusCode == http.StatusTooManyRequests:
+		result.Status = StatusRateLimited
+		return result
+	case !containsStatus(custom.ActiveStatus, resp.StatusCode):
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+		return result
+	}
+
+	if custom.ActiveField == "" && len(custom.Details) == 0 {
+		result.Status, result.Note = StatusActive, fmt.Sprintf("Accepted by %s", host)
+		return result
+	}
+
+	var answer interface{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&answer); err != nil {
+		result.Status, result.Note = StatusError, fmt.Sprintf("Malformed response from %s: not JSON", host)
+		return result
+	}
+
+	if custom.ActiveField != "" {
+		value, ok := lookupJSONPath(answer, custom.ActiveField)
+		if !ok {
+			result.Status, result.Note = StatusError, fmt.Sprintf("Malformed response from %s: no %s", host, custom.ActiveField)
+			return result
+		}
+		if !jsonTruthy(value) {
+			result.Status, result.Note = StatusInvalid, fmt.Sprintf("%s says %s is false", host, custom.ActiveField)
+			return result
+		}
+	}
+
+	var described []string
+	for name, path := range custom.Details {
+		value, ok := lookupJSONPath(answer, path)
+		text := jsonText(value)
+		if !ok || text == "" || strings.Contains(text, secret) {
+			continue // Never report the secret back
+		}
+		if result.Details == nil {
+			result.Details = make(map[string]string)
+		}
+		result.Details[name] = text
+		described = append(described, fmt.Sprintf("%s: %s", name, text))
+	}
+	sort.Strings(described)
+
+	result.Status, result.Note = StatusActive, fmt.Sprintf("Accepted by %s", host)
+	if len(described) > 0 {
+		result.Note += " (" + strings.Join(described, ", ") + ")"
+	}
+	return result
+}
+
+// templateHost returns the host (and port) of a URL template as written, placeholders
+// included
+func templateHost(template string) string {
+	_, rest, ok := strings.Cut(template, "://")
+	if !ok {
+		return ""
+	}
+	if end := strings.IndexAny(rest, "/?#"); end >= 0 {
+		rest = rest[:end]
+	}
+	if at := strings.LastIndex(rest, "@"); at >= 0 {
+		rest = rest[at+1:] // After any user info
+	}
+	return rest
+}
+
+// escapeForBody escapes a secret for the body's Content-Type: form-encoded or as the
+// inside of a JSON string, and unchanged for anything else
+func escapeForBody(secret string, headers map[string]string) string {
+	var contentType string
+	for name, value := range headers {
+		if strings.EqualFold(name, "Content-Type") {
+			contentType = strings.ToLower(value)
+		}
+	}
+
+	switch {
+	case strings.Contains(contentType, "application/x-www-form-urlencoded"):
+		return url.QueryEscape(secret)
+	case strings.Contains(contentType, "json"):
+		quoted, _ := json.Marshal(secret)
+		return string(quoted[1 : len(quoted)-1])
+	default:
+		return secret
+	}
+}
+
+// containsStatus reports whether status is one of statuses
+func containsStatus(statuses []int, status int) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonPathStep is one step of a JSON path: an object key, or an array index
+type jsonPathStep struct {
+	key   string
+	index int // -1 for a key step
+}
+
+// parseJSONPath parses a dotted JSON path with array indexes, e.g. "data.scopes[0]"
+func parseJSONPath(path string) ([]jsonPathStep, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("empty JSON path")
+	}
+
+	var steps []jsonPathStep
+	for _, segment := range strings.Split(path, ".") {
+		key, rest, _ := strings.Cut(segment, "[")
+		if key == "" && rest == "" {
+			return nil, fmt.Errorf("empty segment in JSON path %q", path)
+		}
+		if key != "" {
+			steps = append(steps, jsonPathStep{key: key, index: -1})
+		}
+		for rest != "" {
+			digits, after, ok := strings.Cut(rest, "]")
+			index, err := strconv.Atoi(digits)
+			if !ok || err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid array index in JSON path %q", path)
+			}
+			steps = append(steps, jsonPathStep{index: index})
+			if after == "" {
+				break
+			}
+			if !strings.HasPrefix(after, "[") {
+				return nil, fmt.Errorf("invalid JSON path %q", path)
+			}
+			rest = after[1:]
+		}
+	}
+	return steps, nil
+}
+
+// lookupJSONPath returns the value at path in a decoded JSON document
+func lookupJSONPath(doc interface{}, path string) (interface{}, bool) {
+	steps, err := parseJSONPath(path)
+	if err != nil {
+		return nil, false
+	}
+
+	value := doc
+	for _, step := range steps {
+		if step.index < 0 {
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if value, ok = object[step.key]; !ok {
+				return nil, false
+			}
+			continue
+		}
+		array, ok := value.([]interface{})
+		if !ok || step.index >= len(array) {
+			return nil, false
+		}
+		value = array[step.index]
+	}
+	return value, true
+}
+
+// jsonTruthy reports whether a JSON value says yes: true, a non-zero number, or a string
+// other than "", "false" and "0"
+func jsonTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "", "false", "0":
+			return false
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// jsonText renders a scalar JSON value as text, and arrays of scalars comma-separated;
+// objects and null render as ""
+func jsonText(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if text := jsonText(item); text != "" {
+				parts = append(parts, text)
+			}
+		}
+		return strings.Join(parts, ",")
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,101 @@
+package scanner
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+const datadogAPIKey = "4f8a2c6e0b9d3f7a1c5e8b2d6f0a4c9e"
+
+func TestDetectDatadogKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantType string
+	}{
+		{"API key", `{"dd_api_key": "` + datadogAPIKey + `"}`, "Datadog API Key"},
+		{"application key", `{"DD-APPLICATION-KEY": "9b2d5f8a1c4e7b0d3f6a9c2e5b8d1f4a7c0e3b6d"}`, "Datadog Application Key"},
+		{"MD5 checksum", `{"md5": "9e107d9d372bb6826bd81d3542a419d6"}`, ""},
+		{"request ID", `{"request_id": "` + datadogAPIKey + `"}`, ""},
+		{"commit SHA", `{"commit": "2fd4e1c67a2d28fced849ee1bb76e7391b93eb12"}`, ""},
+		{"SHA-1 checksum", `{"sha1": "da39a3ee5e6b4b0d3255bfef95601890afd80709"}`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, _ := NewSecretScanner().ScanCollection(rawRequest("https://example.com/api", tt.body))
+			var found []string
+			for _, match := range matches {
+				found = append(found, match.Type)
+			}
+			if tt.wantType == "" {
+				if len(found) != 0 {
+					t.Errorf("found %v, want nothing", found)
+				}
+				return
+			}
+			if len(found) != 1 || found[0] != tt.wantType {
+				t.Errorf("found %v, want [%s]", found, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestVerifyDatadog(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wantStatus VerificationStatus
+		wantNote   string
+	}{
+		{
+			name:       "active",
+			status:     200,
+			body:       `{"valid": true}`,
+			wantStatus: StatusActive,
+			wantNote:   "Datadog API key is valid (US1 site)",
+		},
+		{
+			name:       "other site",
+			status:     403,
+			body:       `{"errors": ["Forbidden"]}`,
+			wantStatus: StatusInvalid,
+			wantNote:   "Key is not valid for the US1 site",
+		},
+		{
+			name:       "rate limited",
+			status:     429,
+			wantStatus: StatusRateLimited,
+		},
+		{
+			name:       "unexpected",
+			status:     500,
+			wantStatus: StatusError,
+			wantNote:   "Unexpected status: 500",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newCountingServer(t, true, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/validate" || r.Header.Get("DD-API-KEY") != datadogAPIKey {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+			v := newTestVerifier(t)
+			v.SetRetry(1, 0)
+			routeTo(t, v, server)
+
+			result := v.VerifySecret(context.Background(), SecretMatch{Type: "Datadog API Key", RawValue: datadogAPIKey})
+			if result.Status != tt.wantStatus {
+				t.Fatalf("status = %s (%s), want %s", result.Status, result.Note, tt.wantStatus)
+			}
+			if result.Note != tt.wantNote {
+				t.Errorf("note = %q, want %q", result.Note, tt.wantNote)
+			}
+		})
+	}
+}
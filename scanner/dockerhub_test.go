@@ -0,0 +1,75 @@
+package scanner
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestVerifyDockerHub(t *testing.T) {
+	const token = "dckr_pat_Qm7vL4pR8sT1wY6zB3nC5dF0hJk"
+
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantStatus  VerificationStatus
+		wantNote    string
+		wantDetails map[string]string
+	}{
+		{
+			name:        "active",
+			status:      200,
+			body:        `{"id": "8e2b6d0f4a9c", "username": "deploybot", "full_name": "", "type": "User"}`,
+			wantStatus:  StatusActive,
+			wantNote:    "Docker Hub token for 'deploybot'",
+			wantDetails: map[string]string{"login": "deploybot"},
+		},
+		{
+			name:       "invalid",
+			status:     401,
+			body:       `{"detail": "Incorrect authentication credentials."}`,
+			wantStatus: StatusInvalid,
+			wantNote:   "Token not valid",
+		},
+		{
+			name:       "rate limited",
+			status:     429,
+			wantStatus: StatusRateLimited,
+		},
+		{
+			name:       "unexpected",
+			status:     418,
+			wantStatus: StatusError,
+			wantNote:   "Unexpected status: 418",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newCountingServer(t, true, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v2/user/" || r.Header.Get("Authorization") != "Bearer "+token {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+			v := newTestVerifier(t)
+			v.SetRetry(1, 0)
+			routeTo(t, v, server)
+
+			result := v.VerifySecret(context.Background(), SecretMatch{Type: "Docker Hub Token", RawValue: token})
+			if result.Status != tt.wantStatus {
+				t.Fatalf("status = %s (%s), want %s", result.Status, result.Note, tt.wantStatus)
+			}
+			if result.Note != tt.wantNote {
+				t.Errorf("note = %q, want %q", result.Note, tt.wantNote)
+			}
+			for key, want := range tt.wantDetails {
+				if result.Details[key] != want {
+					t.Errorf("details[%s] = %q, want %q", key, result.Details[key], want)
+				}
+			}
+		})
+	}
+}
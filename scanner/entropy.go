@@ -0,0 +1,56 @@
+package scanner
+
+import (
+	"math"
+	"unicode"
+)
+
+// defaultMinEntropy separates random keys (~4 bits/char and up) from names and words
+const defaultMinEntropy = 3.5
+
+// shannonEntropy returns the average information per character of s, in bits
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// looksRandom reports whether a value looks like a generated secret rather than a name
+// such as my-service-production-environment-name: it needs minEntropy bits/char and at
+// least two of lower case letters, upper case letters and digits
+func looksRandom(value string, minEntropy float64) bool {
+	var lower, upper, digit bool
+	for _, r := range value {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		}
+	}
+
+	classes := 0
+	for _, present := range []bool{lower, upper, digit} {
+		if present {
+			classes++
+		}
+	}
+
+	return classes >= 2 && shannonEntropy(value) >= minEntropy
+}
@@ -0,0 +1,83 @@
+package scanner
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestLooksRandom(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"Xk9mQ2vL7pR4sT8wY1zB6nC3dF5hJ0gA", true},
+		{"my-service-production-environment-name", false},
+		{"aaaaaaaaaaaaaaaaaaaaaaaa1", false},  // Two classes, but no entropy
+		{"qwertyuiopasdfghjklzxcvbnm", false}, // Entropy, but one class
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := looksRandom(tt.value, defaultMinEntropy); got != tt.want {
+				t.Errorf("looksRandom(%q) = %t, want %t", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// genericCollection builds a collection of about size bytes whose request bodies are
+// config files full of generic api_key=/secret: entries, half random keys and half
+// repetitive low-entropy values
+func genericCollection(size int) map[string]interface{} {
+	random := rand.New(rand.NewSource(1))
+	const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	key := func(n int) string {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = alphabet[random.Intn(len(alphabet))]
+		}
+		return string(b)
+	}
+
+	var requests []interface{}
+	for written := 0; written < size; {
+		var body strings.Builder
+		for i := 0; i < 50; i++ {
+			fmt.Fprintf(&body, "service_%d_api_key = %s\n", i, key(32))
+			fmt.Fprintf(&body, "service_%d_secret: \"aaaa-bbbb-cccc-dddd-%06d\"\n", i, len(requests)*50+i)
+		}
+		requests = append(requests, map[string]interface{}{
+			"name": fmt.Sprintf("Config %d", len(requests)),
+			"request": map[string]interface{}{
+				"method": "PUT",
+				"url":    "https://config.example.com/v1/services",
+				"body":   map[string]interface{}{"mode": "raw", "raw": body.String()},
+			},
+		})
+		written += body.Len()
+	}
+	return map[string]interface{}{"item": requests}
+}
+
+func BenchmarkMinEntropy(b *testing.B) {
+	collection := genericCollection(1 << 20)
+
+	for _, bench := range []struct {
+		name       string
+		minEntropy float64
+	}{
+		{"default", defaultMinEntropy},
+		// SetMinEntropy ignores 0, so the threshold is lowered directly; every value with
+		// two character classes is then reported
+		{"no threshold", 0},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			s := NewSecretScanner()
+			s.minEntropy = bench.minEntropy
+			for i := 0; i < b.N; i++ {
+				s.ScanCollection(collection)
+			}
+		})
+	}
+}
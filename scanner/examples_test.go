@@ -0,0 +1,95 @@
+package scanner
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScanExampleResponses(t *testing.T) {
+	token := testJWT(t, map[string]interface{}{"sub": "user-8812", "iss": "https://auth.acme.io", "exp": 4102444800})
+	padding := strings.Repeat(`{"id": 1, "status": "ok"},`, 100) // 2.6 KB
+
+	example := func(body string) map[string]interface{} {
+		return map[string]interface{}{
+			"item": []interface{}{map[string]interface{}{
+				"name":    "Login",
+				"request": map[string]interface{}{"method": "POST", "url": "https://api.acme.io/login"},
+				"response": []interface{}{map[string]interface{}{
+					"name": "Success",
+					"code": 200,
+					"body": body,
+				}},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		jsonScan bool
+		want     bool
+	}{
+		{"token in the body", `{"access_token": "` + token + `"}`, false, true},
+		{"token in the body, JSON pass on", `{"access_token": "` + token + `"}`, true, true},
+		{"token past the limit", "[" + padding + `{"access_token": "` + token + `"}]`, false, false},
+		{"token past the limit, JSON pass on", "[" + padding + `{"access_token": "` + token + `"}]`, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSecretScanner()
+			s.SetMaxExampleBody(1024)
+			s.SetCollectionJSONScan(tt.jsonScan)
+			collection := example(tt.body)
+
+			var found *SecretMatch
+			matches, _ := s.ScanCollection(collection)
+			for i := range matches {
+				if matches[i].Type == "JWT Token" && matches[i].RawValue == token {
+					found = &matches[i]
+				}
+			}
+			if (found != nil) != tt.want {
+				t.Fatalf("JWT found = %v, want %v (matches: %v)", found != nil, tt.want, matches)
+			}
+			if found != nil && !strings.Contains(found.JSONPath, "response[0].body") {
+				t.Errorf("JSONPath = %q, want the example body", found.JSONPath)
+			}
+
+			body := collection["item"].([]interface{})[0].(map[string]interface{})["response"].([]interface{})[0].(map[string]interface{})["body"]
+			if body != tt.body {
+				t.Error("scanning modified the collection's example body")
+			}
+		})
+	}
+}
+
+func TestTruncateExampleBodies(t *testing.T) {
+	request := map[string]interface{}{"body": map[string]interface{}{"mode": "raw", "raw": "0123456789"}}
+	data := map[string]interface{}{
+		"request":  request,
+		"response": []interface{}{map[string]interface{}{"body": "0123456789"}, map[string]interface{}{"body": "0123"}},
+	}
+
+	got, changed := truncateExampleBodies(data, 4)
+	if !changed {
+		t.Fatal("nothing truncated")
+	}
+	gotMap := got.(map[string]interface{})
+	responses := gotMap["response"].([]interface{})
+	if body := responses[0].(map[string]interface{})["body"]; body != "0123" {
+		t.Errorf("long body = %v, want 0123", body)
+	}
+	if body := responses[1].(map[string]interface{})["body"]; body != "0123" {
+		t.Errorf("short body = %v, want 0123", body)
+	}
+	if raw := gotMap["request"].(map[string]interface{})["body"].(map[string]interface{})["raw"]; raw != "0123456789" {
+		t.Errorf("request body = %v, want it untouched", raw)
+	}
+	if body := data["response"].([]interface{})[0].(map[string]interface{})["body"]; body != "0123456789" {
+		t.Errorf("original body = %v, want it untouched", body)
+	}
+
+	if _, changed := truncateExampleBodies(data, 100); changed {
+		t.Error("bodies within the limit reported as truncated")
+	}
+}
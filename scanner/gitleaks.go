@@ -0,0 +1,220 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/postman-observer/toml"
+)
+
+// gitleaksRule is the subset of a Gitleaks [[rules]] entry the scanner understands
+type gitleaksRule struct {
+	id, description, regex string
+	keywords               []string
+	entropy                float64
+	secretGroup            int
+	path                   string
+	unsupported            []string // Features present but ignored (allowlists, paths...)
+}
+
+// LoadPatternFile loads the rules of a Gitleaks TOML config (id, description, regex,
+// keywords, entropy, secretGroup) as secret patterns. Rules and features the scanner
+// can't use are reported in skipped instead of failing the load.
+func LoadPatternFile(path string) (patterns []SecretPattern, skipped []string, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read pattern file: %w", err)
+	}
+
+	rules, skipped, err := parseGitleaksTOML(string(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse pattern file %s: %w", path, err)
+	}
+
+	for _, rule := range rules {
+		name := rule.id
+		if name == "" {
+			name = rule.description
+		}
+		if rule.regex == "" {
+			skipped = append(skipped, fmt.Sprintf("rule %q: no regex (path-only rules are not supported)", name))
+			continue
+		}
+
+		compiled, err := regexp.Compile(rule.regex)
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("rule %q: invalid regex: %v", name, err))
+			continue
+		}
+		if rule.secretGroup > compiled.NumSubexp() {
+			skipped = append(skipped, fmt.Sprintf("rule %q: secretGroup %d but the regex has %d group(s)", name, rule.secretGroup, compiled.NumSubexp()))
+			continue
+		}
+		if rule.path != "" {
+			rule.unsupported = append(rule.unsupported, "path")
+		}
+		if len(rule.unsupported) > 0 {
+			skipped = append(skipped, fmt.Sprintf("rule %q: ignoring %s", name, strings.Join(rule.unsupported, ", ")))
+		}
+
+		// Like Gitleaks, a lone capture group holds the secret unless secretGroup says otherwise
+		group := rule.secretGroup
+		if group == 0 && compiled.NumSubexp() == 1 {
+			group = 1
+		}
+
+		description := rule.description
+		if description == "" {
+			description = name
+		}
+
+		keywords := make([]string, 0, len(rule.keywords))
+		for _, keyword := range rule.keywords {
+			if keyword = strings.ToLower(strings.TrimSpace(keyword)); keyword != "" {
+				keywords = append(keywords, keyword)
+			}
+		}
+
+		patterns = append(patterns, SecretPattern{
+			Name:        name,
+			Pattern:     compiled,
+			Description: description,
+			Group:       group,
+			Keywords:    keywords,
+			MinEntropy:  rule.entropy,
+			Confidence:  ConfidenceHigh,
+		})
+	}
+
+	return patterns, skipped, nil
+}
+
+// AddPatterns adds imported patterns to the scanner. An imported pattern replaces a
+// built-in one of the same name. One with the same regex as a pattern the scanner already
+// has is dropped, so the existing type (and its verifier) is kept; duplicates describes
+// each one dropped.
+func (s *SecretScanner) AddPatterns(patterns []SecretPattern) (duplicates []string) {
+	imported := make(map[string]bool, len(patterns))
+	for _, pattern := range patterns {
+		imported[strings.ToLower(pattern.Name)] = true
+	}
+
+	kept := s.patterns[:0]
+	regexes := make(map[string]string, len(s.patterns)+len(patterns))
+	for _, pattern := range s.patterns {
+		if !imported[strings.ToLower(pattern.Name)] {
+			kept = append(kept, pattern)
+			regexes[pattern.Pattern.String()] = pattern.Name
+		}
+	}
+	for _, pattern := range patterns {
+		if existing, ok := regexes[pattern.Pattern.String()]; ok {
+			duplicates = append(duplicates, fmt.Sprintf("rule %q: same regex as the %q pattern, which is kept", pattern.Name, existing))
+			continue
+		}
+		regexes[pattern.Pattern.String()] = pattern.Name
+		kept = append(kept, pattern)
+	}
+	s.patterns = kept
+	return duplicates
+}
+
+// parseGitleaksTOML reads the [[rules]] of a Gitleaks config and records the sections it
+// ignores in skipped
+func parseGitleaksTOML(data string) (rules []gitleaksRule, skipped []string, err error) {
+	root, err := toml.Parse(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, name := range sortedKeys(root) {
+		if name != "rules" && isTOMLTable(root[name]) {
+			skipped = append(skipped, fmt.Sprintf("[%s] section (not supported)", name))
+		}
+	}
+
+	for _, item := range asList(root["rules"]) {
+		if table, ok := item.(map[string]interface{}); ok {
+			rules = append(rules, gitleaksRuleFrom(table))
+		}
+	}
+	return rules, skipped, nil
+}
+
+// gitleaksRuleFrom reads one [[rules]] table. Nested tables ([rules.allowlist] and the
+// like) are features the scanner doesn't support.
+func gitleaksRuleFrom(table map[string]interface{}) gitleaksRule {
+	var rule gitleaksRule
+	for _, key := range sortedKeys(table) {
+		value := table[key]
+		switch key {
+		case "id":
+			rule.id, _ = value.(string)
+		case "description":
+			rule.description, _ = value.(string)
+		case "regex":
+			rule.regex, _ = value.(string)
+		case "keywords":
+			for _, item := range asList(value) {
+				if keyword, ok := item.(string); ok {
+					rule.keywords = append(rule.keywords, keyword)
+				}
+			}
+		case "entropy":
+			rule.entropy, _ = value.(float64)
+		case "secretGroup":
+			if group, ok := value.(float64); ok {
+				rule.secretGroup = int(group)
+			}
+		case "path":
+			rule.path, _ = value.(string)
+		default:
+			if key == "allowlist" || key == "allowlists" || isTOMLTable(value) {
+				rule.unsupported = append(rule.unsupported, key)
+			}
+		}
+	}
+	return rule
+}
+
+// isTOMLTable reports whether a parsed TOML value is a table or an array of tables
+func isTOMLTable(value interface{}) bool {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		return true
+	case []interface{}:
+		if len(value) > 0 {
+			_, ok := value[0].(map[string]interface{})
+			return ok
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of a TOML table in order, so skips are reported stably
+func sortedKeys(table map[string]interface{}) []string {
+	keys := make([]string, 0, len(table))
+	for key := range table {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// containsAny reports whether text contains any of the words
+func containsAny(text string, words []string) bool {
+	for _, word := range words {
+		if strings.Contains(text, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func asList(value interface{}) []interface{} {
+	list, _ := value.([]interface{})
+	return list
+}
@@ -0,0 +1,99 @@
+package scanner
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestVerifier returns a verifier that doesn't space out requests or wait long between
+// retries, so tests against local servers run quickly
+func newTestVerifier(t *testing.T) *SecretVerifier {
+	t.Helper()
+	v := NewSecretVerifier()
+	v.httpClient.pacer.interval = 0
+	v.SetRetry(defaultVerifyAttempts, time.Millisecond)
+	v.SetCacheTTL(0)
+	return v
+}
+
+// countingServer is a test server recording the paths it was asked for
+type countingServer struct {
+	*httptest.Server
+	mu    sync.Mutex
+	paths []string
+}
+
+func newCountingServer(t *testing.T, tls bool, handler http.HandlerFunc) *countingServer {
+	t.Helper()
+	s := &countingServer{}
+	record := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.paths = append(s.paths, r.URL.Path)
+		s.mu.Unlock()
+		handler(w, r)
+	})
+	if tls {
+		s.Server = httptest.NewTLSServer(record)
+	} else {
+		s.Server = httptest.NewServer(record)
+	}
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *countingServer) requested() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.paths...)
+}
+
+// testJWT builds an RS256 JWT with the given claims and a signature nobody checks
+func testJWT(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte("not-a-real-signature-but-long-enough"))
+}
+
+// routeTo sends every request the verifier makes, whatever its provider host, to server,
+// keeping the path and query
+func routeTo(t *testing.T, v *SecretVerifier, server *countingServer) {
+	t.Helper()
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := server.Client().Transport
+	v.SetTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.URL.Scheme, req.URL.Host = target.Scheme, target.Host
+		return transport.RoundTrip(req)
+	}))
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// rawRequest builds a collection with one request to url sending body as raw text
+func rawRequest(url, body string) map[string]interface{} {
+	return map[string]interface{}{"item": []interface{}{map[string]interface{}{
+		"name": "Request",
+		"request": map[string]interface{}{
+			"method": "POST",
+			"url":    url,
+			"body":   map[string]interface{}{"mode": "raw", "raw": body},
+		},
+	}}}
+}
@@ -0,0 +1,96 @@
+package scanner
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestVerifyHeroku(t *testing.T) {
+	const key = "3f2b1c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"
+
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantStatus  VerificationStatus
+		wantNote    string
+		wantDetails map[string]string
+	}{
+		{
+			name:        "active",
+			status:      200,
+			body:        `{"id": "01234567-89ab-cdef-0123-456789abcdef", "email": "ops@example.com"}`,
+			wantStatus:  StatusActive,
+			wantNote:    "Heroku account ops@example.com",
+			wantDetails: map[string]string{"email": "ops@example.com", "account_id": "01234567-89ab-cdef-0123-456789abcdef"},
+		},
+		{
+			name:       "revoked",
+			status:     401,
+			body:       `{"id": "unauthorized", "message": "Invalid credentials provided."}`,
+			wantStatus: StatusInvalid,
+			wantNote:   "API key not valid",
+		},
+		{
+			name:       "suspended account",
+			status:     403,
+			body:       `{"id": "suspended", "message": "This account has been suspended."}`,
+			wantStatus: StatusInvalid,
+			wantNote:   "Account is suspended",
+		},
+		{
+			name:        "authenticated, account refused",
+			status:      403,
+			body:        `{"id": "forbidden", "message": "You do not have access to the account."}`,
+			wantStatus:  StatusActive,
+			wantNote:    "Key authenticated but may not read the account (forbidden)",
+			wantDetails: map[string]string{"refused": "forbidden"},
+		},
+		{
+			name:       "authenticated, no refusal reason",
+			status:     403,
+			wantStatus: StatusActive,
+			wantNote:   "Key authenticated but may not read the account",
+		},
+		{
+			name:       "rate limited",
+			status:     429,
+			wantStatus: StatusRateLimited,
+		},
+		{
+			name:       "unexpected",
+			status:     418,
+			wantStatus: StatusError,
+			wantNote:   "Unexpected status: 418",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newCountingServer(t, true, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/account" || r.Header.Get("Authorization") != "Bearer "+key {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+			v := newTestVerifier(t)
+			v.SetRetry(1, 0)
+			routeTo(t, v, server)
+
+			result := v.VerifySecret(context.Background(), SecretMatch{Type: "Heroku API Key", RawValue: key})
+			if result.Status != tt.wantStatus {
+				t.Fatalf("status = %s (%s), want %s", result.Status, result.Note, tt.wantStatus)
+			}
+			if result.Note != tt.wantNote {
+				t.Errorf("note = %q, want %q", result.Note, tt.wantNote)
+			}
+			for key, want := range tt.wantDetails {
+				if result.Details[key] != want {
+					t.Errorf("details[%s] = %q, want %q", key, result.Details[key], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package scanner
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// jwksTTL is how long an issuer's signing keys are reused before being fetched again
+const jwksTTL = time.Hour
+
+// jwk is one key of a JSON Web Key Set; only RSA and EC public key fields are read
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// jwkSet is an issuer's published keys as cached by the verifier
+type jwkSet struct {
+	keys      []jwk
+	fetchedAt time.Time
+}
+
+// jwtAsymmetric are the public-key signing algorithms verifyJWT can check: the hash and
+// the key type each one needs
+var jwtAsymmetric = map[string]struct {
+	hash crypto.Hash
+	kty  string
+}{
+	"RS256": {crypto.SHA256, "RSA"},
+	"RS384": {crypto.SHA384, "RSA"},
+	"RS512": {crypto.SHA512, "RSA"},
+	"ES256": {crypto.SHA256, "EC"},
+	"ES384": {crypto.SHA384, "EC"},
+}
+
+// Outcomes of checking a JWT's signature against its issuer's keys
+var (
+	errJWTSignature = errors.New("signature invalid")
+	errJWTNoKey     = errors.New("signing key not published by the issuer")
+)
+
+// errPrivateAddress stops a request whose host resolves to an address that isn't public
+var errPrivateAddress = errors.New("host resolves to a private address")
+
+// publicAddress reports whether ip is on the public internet: not private, loopback,
+// link-local (e.g. cloud metadata at 169.254.169.254), multicast or unspecified
+func publicAddress(ip net.IP) bool {
+	return !(ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
+
+// issuerURL returns a JWT issuer as a URL worth fetching keys from: HTTPS, on a public DNS
+// name. Issuers that are plain names, IPs or internal hosts are never contacted.
+func issuerURL(iss string) (*url.URL, bool) {
+	u, err := url.Parse(strings.TrimSpace(iss))
+	if err != nil || u.Scheme != "https" || u.User != nil {
+		return nil, false
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "" || net.ParseIP(host) != nil || !strings.Contains(host, ".") ||
+		host == "localhost" || strings.HasSuffix(host, ".local") || strings.HasSuffix(host, ".internal") {
+		return nil, false
+	}
+	return u, true
+}
+
+// checkJWTIssuerSignature verifies an asymmetrically signed JWT against the keys its issuer
+// publishes. It returns the kid used, nil for a valid signature, errJWTSignature or
+// errJWTNoKey for a token the issuer didn't sign, or the error that stopped the check.
+func (v *SecretVerifier) checkJWTIssuerSignature(ctx context.Context, token string, issuer *url.URL) (string, error) {
+	header, _, _ := strings.Cut(token, ".")
+	decoded, err := decodeJWTSegment(header)
+	if err != nil {
+		return "", errJWTMalformed
+	}
+	var fields struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if json.Unmarshal(decoded, &fields) != nil {
+		return "", errJWTMalformed
+	}
+	algorithm, ok := jwtAsymmetric[fields.Alg]
+	if !ok {
+		return fields.Kid, fmt.Errorf("unsupported algorithm %s", fields.Alg)
+	}
+
+	keys, err := v.issuerKeys(ctx, issuer)
+	if err != nil {
+		return fields.Kid, err
+	}
+
+	// Without a kid, any key of the right type may have signed the token
+	var candidates []jwk
+	for _, key := range keys {
+		if key.Kty == algorithm.kty && (fields.Kid == "" || key.Kid == fields.Kid) {
+			candidates = append(candidates, key)
+		}
+	}
+	if len(candidates) == 0 {
+		return fields.Kid, errJWTNoKey
+	}
+
+	signingInput := token[:strings.LastIndexByte(token, '.')]
+	signature, err := decodeJWTSegment(token[len(signingInput)+1:])
+	if err != nil {
+		return fields.Kid, errJWTSignature
+	}
+	hasher := algorithm.hash.New()
+	hasher.Write([]byte(signingInput))
+	digest := hasher.Sum(nil)
+
+	for _, key := range candidates {
+		if key.verify(algorithm.hash, digest, signature) {
+			return key.Kid, nil
+		}
+	}
+	return fields.Kid, errJWTSignature
+}
+
+// verify checks a signature over digest with the key; keys that can't be decoded fail
+func (k jwk) verify(hash crypto.Hash, digest, signature []byte) bool {
+	switch k.Kty {
+	case "RSA":
+		n, errN := decodeJWTSegment(k.N)
+		e, errE := decodeJWTSegment(k.E)
+		if errN != nil || errE != nil || len(e) == 0 || len(e) > 4 {
+			return false
+		}
+		key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		return rsa.VerifyPKCS1v15(key, hash, digest, signature) == nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return false
+		}
+		x, errX := decodeJWTSegment(k.X)
+		y, errY := decodeJWTSegment(k.Y)
+		size := (curve.Params().BitSize + 7) / 8
+		if errX != nil || errY != nil || len(signature) != 2*size {
+			return false
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(key, digest, r, s)
+	}
+	return false
+}
+
+// issuerKeys returns an issuer's signing keys, fetched from the jwks_uri of its OpenID
+// configuration, or <issuer>/.well-known/jwks.json when it has none, and cached for jwksTTL.
+// Issuers come from untrusted tokens, so these requests only reach public addresses.
+func (v *SecretVerifier) issuerKeys(ctx context.Context, issuer *url.URL) ([]jwk, error) {
+	base := strings.TrimRight(issuer.String(), "/")
+
+	v.mu.Lock()
+	cached, ok := v.jwks[base]
+	v.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < jwksTTL {
+		return cached.keys, nil
+	}
+
+	client := v.issuerClient()
+	jwksURL := base + "/.well-known/jwks.json"
+	var discovery struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	err := getJSON(ctx, client, base+"/.well-known/openid-configuration", &discovery)
+	if errors.Is(err, errPrivateAddress) {
+		return nil, err
+	}
+	if err == nil && discovery.JWKSURI != "" {
+		if u, ok := issuerURL(discovery.JWKSURI); ok {
+			jwksURL = u.String()
+		}
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := getJSON(ctx, client, jwksURL, &set); err != nil {
+		return nil, err
+	}
+
+	v.mu.Lock()
+	v.jwks[base] = &jwkSet{keys: set.Keys, fetchedAt: time.Now()}
+	v.mu.Unlock()
+	return set.Keys, nil
+}
+
+// issuerClient returns the verifier's client with a transport that only connects to
+// public addresses (see publicTransport), for hosts named by the scanned data: JWT
+// issuers and Azure SAS endpoints
+func (v *SecretVerifier) issuerClient() *retryClient {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.issuerTransport == nil {
+		v.issuerTransport = newPublicTransport(v.httpClient.Transport, v.addressAllowed)
+	}
+
+	client := *v.httpClient
+	httpClient := *v.httpClient.Client
+	httpClient.Transport = v.issuerTransport
+	client.Client = &httpClient
+	return &client
+}
+
+// publicTransport sends requests only to hosts on public addresses. Direct connections
+// are checked at dial time, after DNS resolution, so neither a hostname pointing at an
+// internal address nor DNS rebinding gets through. Requests through a proxy, which
+// resolves the host itself, are checked by resolving the host first.
+type publicTransport struct {
+	direct  *http.Transport // Dials only allowed addresses
+	proxied *http.Transport // The verifier's transport, for requests its proxy carries
+	allowed func(net.IP) bool
+}
+
+// newPublicTransport wraps base, the verifier's transport (nil for the default), to only
+// reach addresses allowed approves
+func newPublicTransport(base http.RoundTripper, allowed func(net.IP) bool) *publicTransport {
+	proxied, ok := base.(*http.Transport)
+	if !ok || proxied == nil {
+		proxied = http.DefaultTransport.(*http.Transport)
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   allowedAddressesOnly(allowed),
+	}
+	direct := proxied.Clone()
+	direct.Proxy = nil
+	direct.DialContext = dialer.DialContext
+	direct.DialTLSContext = nil
+
+	return &publicTransport{direct: direct, proxied: proxied, allowed: allowed}
+}
+
+// allowedAddressesOnly is a net.Dialer Control function refusing, with errPrivateAddress,
+// to connect to an address allowed rejects. It sees the resolved IP the dialer is about to
+// connect to, so a hostname can't be re-resolved to an internal one after a check.
+func allowedAddressesOnly(allowed func(net.IP) bool) func(network, address string, _ syscall.RawConn) error {
+	return func(network, address string, _ syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
+		if ip := net.ParseIP(host); err != nil || ip == nil || !allowed(ip) {
+			return errPrivateAddress
+		}
+		return nil
+	}
+}
+
+func (t *publicTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.proxied.Proxy != nil {
+		proxyURL, err := t.proxied.Proxy(req)
+		if err != nil {
+			return nil, err
+		}
+		if proxyURL != nil {
+			ips, err := net.DefaultResolver.LookupIPAddr(req.Context(), req.URL.Hostname())
+			if err != nil {
+				return nil, err
+			}
+			for _, ip := range ips {
+				if !t.allowed(ip.IP) {
+					return nil, errPrivateAddress
+				}
+			}
+			return t.proxied.RoundTrip(req)
+		}
+	}
+	return t.direct.RoundTrip(req)
+}
+
+// getJSON fetches a JSON document of at most 1 MB into target
+func getJSON(ctx context.Context, client *retryClient, endpoint string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", endpoint, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("invalid JSON from %s: %w", endpoint, err)
+	}
+	return nil
+}
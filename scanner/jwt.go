@@ -0,0 +1,186 @@
+package scanner
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+	"time"
+)
+
+// jwtTimeFormat is how JWT timestamps are shown in metadata and verification messages
+const jwtTimeFormat = "2006-01-02 15:04"
+
+// Reasons a JWT's claims can't be read
+var (
+	errJWTMalformed = errors.New("malformed JWT")
+	errJWTEncoding  = errors.New("cannot decode JWT payload")
+	errJWTPayload   = errors.New("invalid JWT payload")
+)
+
+// decodeJWTSegment decodes a base64url JWT segment, tolerating padding and standard base64
+func decodeJWTSegment(segment string) ([]byte, error) {
+	segment = strings.TrimRight(segment, "=")
+	decoded, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		decoded, err = base64.RawStdEncoding.DecodeString(segment)
+	}
+	return decoded, err
+}
+
+// jwtClaims decodes the payload of a JWT without checking its signature
+func jwtClaims(token string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errJWTMalformed
+	}
+
+	decoded, err := decodeJWTSegment(parts[1])
+	if err != nil {
+		return nil, errJWTEncoding
+	}
+
+	var claims map[string]interface{}
+	if err := json.Unmarshal(decoded, &claims); err != nil {
+		return nil, errJWTPayload
+	}
+	return claims, nil
+}
+
+// jwtHMACs are the hash functions of the HMAC signing algorithms
+var jwtHMACs = map[string]func() hash.Hash{
+	"HS256": sha256.New,
+	"HS384": sha512.New384,
+	"HS512": sha512.New,
+}
+
+// jwtAlgorithm returns the "alg" of a JWT's header, or "" if it can't be read
+func jwtAlgorithm(token string) string {
+	header, _, _ := strings.Cut(token, ".")
+	decoded, err := decodeJWTSegment(header)
+	if err != nil {
+		return ""
+	}
+	var fields struct {
+		Alg string `json:"alg"`
+	}
+	if json.Unmarshal(decoded, &fields) != nil {
+		return ""
+	}
+	return fields.Alg
+}
+
+// jwtSignedWith reports whether an HMAC-signed (HS256/384/512) JWT was signed with key
+func jwtSignedWith(token, key string) bool {
+	newHash, ok := jwtHMACs[jwtAlgorithm(token)]
+	if !ok || strings.Count(token, ".") != 2 {
+		return false
+	}
+	signingInput := token[:strings.LastIndexByte(token, '.')]
+	signature, err := decodeJWTSegment(token[len(signingInput)+1:])
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(newHash, []byte(key))
+	mac.Write([]byte(signingInput))
+	return hmac.Equal(mac.Sum(nil), signature)
+}
+
+// checkJWTSignatures tries every other secret of the collection as the HMAC key of its
+// HS256/384/512 JWTs, so a token whose signing key leaked alongside it is told apart from
+// one that merely decodes
+func checkJWTSignatures(matches []SecretMatch) {
+	for i := range matches {
+		jwt := &matches[i]
+		if jwt.Type != "JWT Token" || jwtHMACs[jwtAlgorithm(jwt.RawValue)] == nil {
+			continue
+		}
+		if jwt.Metadata == nil {
+			jwt.Metadata = make(map[string]string)
+		}
+		jwt.Metadata["signature"] = "not verified"
+
+		for _, key := range matches {
+			if key.Type == "JWT Token" || !jwtSignedWith(jwt.RawValue, key.RawValue) {
+				continue
+			}
+			jwt.Metadata["signature"] = fmt.Sprintf("verified with the %s at %s", key.Type, key.Location)
+			jwt.Confidence = ConfidenceHigh
+			break
+		}
+	}
+}
+
+// jwtMetadata extracts the triage-relevant claims of a JWT: issuer, audience, subject,
+// scopes and expiry. Tokens whose payload isn't JSON yield no metadata.
+func jwtMetadata(token string) map[string]string {
+	claims, err := jwtClaims(token)
+	if err != nil {
+		return nil
+	}
+
+	metadata := make(map[string]string)
+	if alg := jwtAlgorithm(token); alg != "" {
+		metadata["alg"] = alg
+	}
+	for _, claim := range []string{"iss", "aud", "sub"} {
+		if value := claimString(claims[claim]); value != "" {
+			metadata[claim] = value
+		}
+	}
+
+	// OAuth servers use "scope" (space-separated) or "scp" (a list) for the granted scopes
+	scope := claimString(claims["scope"])
+	if scope == "" {
+		scope = claimString(claims["scp"])
+	}
+	if scope != "" {
+		metadata["scope"] = scope
+	}
+
+	if exp, ok := claims["exp"].(float64); ok {
+		expTime := time.Unix(int64(exp), 0)
+		metadata["exp"] = expTime.Format(jwtTimeFormat)
+		metadata["expired"] = fmt.Sprintf("%t", time.Now().After(expTime))
+	}
+
+	if len(metadata) == 0 {
+		return nil
+	}
+	return metadata
+}
+
+// claimString renders a string, number or list claim; other claim types render as ""
+func claimString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return fmt.Sprintf("%.0f", v)
+	case []interface{}:
+		var parts []string
+		for _, item := range v {
+			if s := claimString(item); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, " ")
+	}
+	return ""
+}
+
+// describeJWT attaches a JWT's claims to its match; expired tokens are downgraded to low
+// severity, as they no longer grant access
+func describeJWT(match *SecretMatch) {
+	match.Metadata = jwtMetadata(match.RawValue)
+	if match.Metadata["expired"] == "true" {
+		match.Severity = "low"
+		match.Description += fmt.Sprintf(" (expired %s)", match.Metadata["exp"])
+	}
+}
@@ -0,0 +1,119 @@
+package scanner
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestVerifyJWTWithoutSignatureCheck(t *testing.T) {
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"admin","exp":4102444800}`)) + "."
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"no issuer", testJWT(t, map[string]interface{}{"sub": "a", "exp": 4102444800})},
+		{"unsigned", unsigned},
+		{"issuer that is never contacted", testJWT(t, map[string]interface{}{"iss": "https://127.0.0.1", "exp": 4102444800})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newTestVerifier(t)
+
+			result := v.VerifySecret(context.Background(), SecretMatch{Type: "JWT Token", RawValue: tt.token})
+			if result.Status != StatusUnsupported || result.IsValid {
+				t.Errorf("status = %s, valid = %v (%s), want unsupported and not valid", result.Status, result.IsValid, result.Note)
+			}
+		})
+	}
+}
+
+func TestJWTClaims(t *testing.T) {
+	segment := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	header := segment(`{"alg":"RS256","typ":"JWT"}`)
+
+	tests := []struct {
+		name         string
+		token        string
+		wantMetadata map[string]string // nil: no metadata
+		wantSeverity string
+		wantStatus   VerificationStatus
+		wantNote     string
+	}{
+		{
+			name:         "expired",
+			token:        testJWT(t, map[string]interface{}{"sub": "user-1", "aud": "api", "exp": 946684800}),
+			wantMetadata: map[string]string{"sub": "user-1", "aud": "api", "expired": "true", "alg": "RS256"},
+			wantSeverity: "low",
+			wantStatus:   StatusExpired,
+			wantNote:     "Token expired at",
+		},
+		{
+			name:         "live",
+			token:        testJWT(t, map[string]interface{}{"sub": "user-1", "scope": "read write", "exp": 4102444800}),
+			wantMetadata: map[string]string{"sub": "user-1", "scope": "read write", "expired": "false"},
+			wantStatus:   StatusUnsupported,
+			wantNote:     "expires at",
+		},
+		{
+			name:         "missing exp",
+			token:        testJWT(t, map[string]interface{}{"iss": "issuer", "scp": []interface{}{"a", "b"}}),
+			wantMetadata: map[string]string{"iss": "issuer", "scope": "a b"},
+			wantStatus:   StatusUnsupported,
+			wantNote:     "no expiration",
+		},
+		{
+			name:       "payload not JSON",
+			token:      header + "." + segment("not json at all") + "." + segment("signature"),
+			wantStatus: StatusInvalid,
+			wantNote:   "Invalid JWT payload",
+		},
+		{
+			name:       "payload not base64",
+			token:      header + ".!!!!." + segment("signature"),
+			wantStatus: StatusInvalid,
+			wantNote:   "Cannot decode JWT payload",
+		},
+		{
+			name:       "malformed",
+			token:      header + "." + segment(`{"sub":"a"}`),
+			wantStatus: StatusInvalid,
+			wantNote:   "Malformed JWT",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := SecretMatch{Type: "JWT Token", RawValue: tt.token, Severity: "high"}
+			describeJWT(&match)
+			if tt.wantMetadata == nil && match.Metadata != nil {
+				t.Errorf("metadata = %v, want none", match.Metadata)
+			}
+			for key, want := range tt.wantMetadata {
+				if match.Metadata[key] != want {
+					t.Errorf("metadata[%s] = %q, want %q", key, match.Metadata[key], want)
+				}
+			}
+			if _, ok := match.Metadata["exp"]; ok != (tt.wantMetadata["expired"] != "") {
+				t.Errorf("metadata exp = %q, want it only with an exp claim", match.Metadata["exp"])
+			}
+			wantSeverity := tt.wantSeverity
+			if wantSeverity == "" {
+				wantSeverity = "high"
+			}
+			if match.Severity != wantSeverity {
+				t.Errorf("severity = %q, want %q", match.Severity, wantSeverity)
+			}
+
+			result := newTestVerifier(t).VerifySecret(context.Background(), match)
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %s (%s), want %s", result.Status, result.Note, tt.wantStatus)
+			}
+			if !strings.Contains(result.Note, tt.wantNote) {
+				t.Errorf("note = %q, want it to mention %q", result.Note, tt.wantNote)
+			}
+		})
+	}
+}
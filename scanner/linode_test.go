@@ -0,0 +1,76 @@
+package scanner
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestVerifyLinode(t *testing.T) {
+	const token = "9c4e2a7f1b8d3e6a0c5f9b2d7e4a1c8f3b6d0e9a2c7f5b1d8e4a3c6f0b9d2e7a"
+
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantStatus  VerificationStatus
+		wantNote    string
+		wantDetails map[string]string
+	}{
+		{
+			name:        "active",
+			status:      200,
+			body:        `{"username": "deploy-bot", "email": "ops@example.com", "restricted": false, "two_factor_auth": true}`,
+			wantStatus:  StatusActive,
+			wantNote:    "Linode user 'deploy-bot' (ops@example.com)",
+			wantDetails: map[string]string{"login": "deploy-bot", "email": "ops@example.com"},
+		},
+		{
+			name:       "invalid",
+			status:     401,
+			body:       `{"errors": [{"reason": "Invalid Token"}]}`,
+			wantStatus: StatusInvalid,
+			wantNote:   "Token not valid",
+		},
+		{
+			name:       "rate limited",
+			status:     429,
+			body:       `{"errors": [{"reason": "Too Many Requests"}]}`,
+			wantStatus: StatusRateLimited,
+		},
+		{
+			name:       "unexpected",
+			status:     503,
+			wantStatus: StatusError,
+			wantNote:   "Unexpected status: 503",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newCountingServer(t, true, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v4/profile" || r.Header.Get("Authorization") != "Bearer "+token {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+			v := newTestVerifier(t)
+			v.SetRetry(1, 0)
+			routeTo(t, v, server)
+
+			result := v.VerifySecret(context.Background(), SecretMatch{Type: "Linode Token", RawValue: token})
+			if result.Status != tt.wantStatus {
+				t.Fatalf("status = %s (%s), want %s", result.Status, result.Note, tt.wantStatus)
+			}
+			if result.Note != tt.wantNote {
+				t.Errorf("note = %q, want %q", result.Note, tt.wantNote)
+			}
+			for key, want := range tt.wantDetails {
+				if result.Details[key] != want {
+					t.Errorf("details[%s] = %q, want %q", key, result.Details[key], want)
+				}
+			}
+		})
+	}
+}
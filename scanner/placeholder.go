@@ -0,0 +1,205 @@
+package scanner
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// postmanVariablePattern matches Postman variables: {{api_key}} and dynamic ones like {{$guid}}
+var postmanVariablePattern = regexp.MustCompile(`\{\{[^{}]*\}\}`)
+
+// pathParamPattern matches a path parameter placeholder such as :apiKey
+var pathParamPattern = regexp.MustCompile(`^:[A-Za-z_][A-Za-z0-9_]*$`)
+
+// isPlaceholder reports whether a match only holds Postman placeholders rather than a
+// secret. The captured value (the last submatch, or the whole match) must not be just a
+// {{variable}} or :param, and with embedded {{variables}} removed the pattern must still
+// match - so "key={{token}}" can't reach a length minimum through the variable name.
+func isPlaceholder(pattern *regexp.Regexp, data string, loc []int) bool {
+	match := data[loc[0]:loc[1]]
+
+	value := capturedValue(data, loc)
+	if pathParamPattern.MatchString(value) {
+		return true
+	}
+	if strings.TrimSpace(postmanVariablePattern.ReplaceAllString(value, "")) == "" {
+		return true
+	}
+
+	if stripped := postmanVariablePattern.ReplaceAllString(match, ""); stripped != match {
+		return !pattern.MatchString(stripped)
+	}
+	return false
+}
+
+// capturedValue returns the secret part of a regex match: its longest submatch, unless
+// that is only a small part of the match (e.g. the AKIA prefix of an AWS key)
+func capturedValue(data string, loc []int) string {
+	value := data[loc[0]:loc[1]]
+	longest := ""
+	for i := 1; i < len(loc)/2; i++ {
+		if loc[2*i] >= 0 && loc[2*i+1]-loc[2*i] > len(longest) {
+			longest = data[loc[2*i]:loc[2*i+1]]
+		}
+	}
+	if 2*len(longest) >= len(value) {
+		value = longest
+	}
+	return strings.Trim(value, `'" `)
+}
+
+// exampleWords mark documentation values like YOUR_API_KEY_HERE; extended via SetPlaceholderOptions
+var exampleWords = []string{"YOUR", "EXAMPLE", "CHANGEME", "CHANGE_ME", "INSERT", "XXXX", "REPLACE", "PLACEHOLDER", "REDACTED"}
+
+// possibleExampleWords suggest, but don't prove, a made-up value
+var possibleExampleWords = []string{"SAMPLE", "DUMMY", "FAKE"}
+
+// sequentialOrder ranks characters for spotting sequential values like 1234567890abcdef
+const sequentialOrder = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// segmentSeparators split a value into the parts between prefixes and separators (sk_live_xxxx -> xxxx)
+var segmentSeparators = regexp.MustCompile(`[_\-.:/+=]+`)
+
+// placeholderKind reports why a secret value looks like an example rather than a real
+// secret ("" if it doesn't), and whether that reason is only borderline
+func (s *SecretScanner) placeholderKind(value string) (reason string, borderline bool) {
+	reason, borderline = s.exampleValueKind(value)
+	if reason != "" {
+		return reason, borderline
+	}
+
+	// Basic auth and similar values hide the example inside base64
+	if decoded, err := base64.StdEncoding.DecodeString(value); err == nil && utf8.Valid(decoded) {
+		return s.exampleValueKind(string(decoded))
+	}
+	return "", false
+}
+
+// exampleValueKind applies the placeholder heuristics to a plain value
+func (s *SecretScanner) exampleValueKind(value string) (reason string, borderline bool) {
+	upper := strings.ToUpper(value)
+
+	if strings.HasPrefix(value, "<") && strings.HasSuffix(value, ">") {
+		return "wrapped in angle brackets", false
+	}
+	for _, word := range s.exampleWords {
+		if containsWord(upper, word) {
+			return "contains " + word, false
+		}
+	}
+
+	// The longest segment is the secret body; prefixes such as sk_live_ are ignored
+	body := ""
+	for _, segment := range segmentSeparators.Split(value, -1) {
+		if len(segment) > len(body) {
+			body = segment
+		}
+	}
+	lower := strings.ToLower(body)
+
+	if len(body) >= 4 && strings.Count(body, body[:1]) == len(body) {
+		return "a single repeated character", false
+	}
+	if len(lower) >= 8 && sequentialRatio(lower) >= 0.8 {
+		return "a sequential run of characters", false
+	}
+
+	// Borderline: likely made up, but real keys can look like this
+	for _, word := range possibleExampleWords {
+		if containsWord(upper, word) {
+			return "contains " + word, true
+		}
+	}
+	if len(lower) >= 8 && sequentialRatio(lower[:8]) == 1 {
+		return "starts with a sequential run of characters", true
+	}
+	if len(body) >= 12 && distinctChars(body) <= 3 {
+		return "very few distinct characters", true
+	}
+
+	return "", false
+}
+
+// containsWord reports whether upper (an upper-cased value) contains word between
+// boundaries: the ends of the value, non-alphanumeric characters, or a change between
+// letters and digits (YOUR_KEY, <your-key>, AKIA7EXAMPLE). A word of one repeated
+// character runs on to the end of the run (sk_test_XXXXXXXX), and a run at least twice
+// its length needs no boundaries, as when it is glued to a prefix (AIzaSyXXXXXXXX).
+func containsWord(upper, word string) bool {
+	if word == "" {
+		return false
+	}
+	repeated := strings.Count(word, word[:1]) == len(word)
+
+	for from := 0; from < len(upper); {
+		i := strings.Index(upper[from:], word)
+		if i < 0 {
+			return false
+		}
+		start, end := from+i, from+i+len(word)
+		if repeated {
+			for end < len(upper) && upper[end] == word[0] {
+				end++
+			}
+		}
+		if repeated && end-start >= 2*len(word) {
+			return true
+		}
+		if wordBoundary(upper, start) && wordBoundary(upper, end) {
+			return true
+		}
+		from = start + 1
+	}
+	return false
+}
+
+// wordBoundary reports whether a word may start or end at byte i of s
+func wordBoundary(s string, i int) bool {
+	if i == 0 || i == len(s) {
+		return true
+	}
+	before, after := charClass(s[i-1]), charClass(s[i])
+	return before == 0 || after == 0 || before != after
+}
+
+// charClass is 1 for ASCII letters, 2 for digits and 0 for anything else
+func charClass(c byte) int {
+	switch {
+	case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z':
+		return 1
+	case '0' <= c && c <= '9':
+		return 2
+	}
+	return 0
+}
+
+// sequentialRatio is the share of adjacent characters in s that count up, as in
+// 1234567890abcdef (9 may wrap to 0, and 0 may continue with a)
+func sequentialRatio(s string) float64 {
+	if len(s) < 2 {
+		return 0
+	}
+
+	steps := 0
+	for i := 1; i < len(s); i++ {
+		prev, next := strings.IndexByte(sequentialOrder, s[i-1]), strings.IndexByte(sequentialOrder, s[i])
+		if prev < 0 || next < 0 {
+			continue
+		}
+		if next == prev+1 || (s[i-1] == '9' && s[i] == '0') || (s[i-1] == '0' && s[i] == 'a') {
+			steps++
+		}
+	}
+	return float64(steps) / float64(len(s)-1)
+}
+
+// distinctChars counts the different characters in s
+func distinctChars(s string) int {
+	seen := make(map[rune]bool)
+	for _, r := range s {
+		seen[r] = true
+	}
+	return len(seen)
+}
@@ -0,0 +1,118 @@
+package scanner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultPolicyKey is the verify_types entry covering secrets no other entry names
+const defaultPolicyKey = "default"
+
+// verifyProviders maps each verifiable secret type to the provider name a verification
+// policy can refer to it by
+var verifyProviders = map[string]string{
+	"AWS Access Key":                  "aws",
+	"AWS Credentials":                 "aws",
+	"GitHub Token":                    "github",
+	"GitHub OAuth":                    "github",
+	"GitLab Token":                    "gitlab",
+	"Slack Token":                     "slack",
+	"Slack Webhook":                   "slack",
+	"Google API Key":                  "google",
+	"FCM Server Key":                  "google",
+	"Firebase Database URL":           "firebase",
+	"Stripe Secret Key":               "stripe",
+	"Stripe Restricted Key":           "stripe",
+	"Stripe Publishable Key":          "stripe",
+	"SendGrid API Key":                "sendgrid",
+	"JWT Token":                       "jwt",
+	"Azure Storage Connection String": "azure",
+	"Azure Storage Account Key":       "azure",
+	"Azure SAS Connection String":     "azure",
+	"Database Connection":             "database",
+	"Discord Bot Token":               "discord",
+	"Discord Webhook":                 "discord",
+	"Telegram Bot Token":              "telegram",
+	"Mailgun API Key":                 "mailgun",
+	"Mailchimp API Key":               "mailchimp",
+	"Postmark Server Token":           "postmark",
+	"Heroku API Key":                  "heroku",
+	"PyPI API Token":                  "pypi",
+	"Docker Hub Token":                "dockerhub",
+	"npm Token":                       "npm",
+	"DigitalOcean Token":              "digitalocean",
+	"Linode Token":                    "linode",
+	"Datadog API Key":                 "datadog",
+	"New Relic User API Key":          "newrelic",
+	"New Relic REST API Key":          "newrelic",
+	"Cloudflare API Token":            "cloudflare",
+	"Cloudflare Global API Key":       "cloudflare",
+	"Square Access Token":             "square",
+	"PayPal Credentials":              "paypal",
+	"Shopify Admin API Token":         "shopify",
+	"Shopify Custom App Token":        "shopify",
+	"Shopify Private App Token":       "shopify",
+	"Twilio Credentials":              "twilio",
+	"Twilio Account SID":              "twilio",
+}
+
+// VerifyPolicy decides which secrets may be actively verified. Entries name a provider
+// ("stripe") or a secret type ("Stripe Restricted Key"); a type entry wins over its
+// provider's, and "default" covers everything unnamed. An empty policy verifies everything.
+type VerifyPolicy map[string]bool
+
+// ParseVerifyPolicy normalizes a policy's names and returns the names that match no
+// provider or secret type, so typos can be reported instead of silently ignored
+func ParseVerifyPolicy(entries map[string]bool) (policy VerifyPolicy, unknown []string) {
+	known := map[string]bool{defaultPolicyKey: true}
+	for secretType, provider := range verifyProviders {
+		known[strings.ToLower(secretType)] = true
+		known[provider] = true
+	}
+
+	policy = make(VerifyPolicy, len(entries))
+	for name, allowed := range entries {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if !known[key] {
+			unknown = append(unknown, name)
+		}
+		policy[key] = allowed
+	}
+	sort.Strings(unknown)
+	return policy, unknown
+}
+
+// Allows reports whether secrets of the given type may be verified
+func (p VerifyPolicy) Allows(secretType string) bool {
+	if allowed, ok := p[strings.ToLower(secretType)]; ok {
+		return allowed
+	}
+	if allowed, ok := p[verifyProviders[secretType]]; ok {
+		return allowed
+	}
+	if allowed, ok := p[defaultPolicyKey]; ok {
+		return allowed
+	}
+	return true
+}
+
+// SetVerifyPolicy restricts which secret types VerifySecret contacts providers for
+func (v *SecretVerifier) SetVerifyPolicy(policy VerifyPolicy) {
+	v.policy = policy
+}
+
+// policyResult is the result recorded for a secret the policy forbids verifying
+func policyResult(secret SecretMatch) *VerificationResult {
+	name := verifyProviders[secret.Type]
+	if name == "" {
+		name = secret.Type
+	}
+	result := &VerificationResult{
+		Status:     StatusSkipped,
+		Note:       fmt.Sprintf("Verification disabled by policy (%s)", name),
+		VerifiedAt: time.Now(),
+	}
+	return result.finish()
+}
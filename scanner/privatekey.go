@@ -0,0 +1,171 @@
+package scanner
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// privateKeyPattern matches a whole PEM private key block, BEGIN through END. Escaped
+// newlines (\n inside JSON strings) are part of the body and normalized afterwards.
+// The second alternative catches a lone BEGIN header when the block is truncated.
+var privateKeyPattern = regexp.MustCompile(
+	`-----BEGIN ((?:RSA |DSA |EC |OPENSSH |ENCRYPTED )?PRIVATE KEY)-----(?:[^-]|-[^-])*?-----END (?:RSA |DSA |EC |OPENSSH |ENCRYPTED )?PRIVATE KEY-----` +
+		`|-----BEGIN\s(?:RSA|DSA|EC|OPENSSH)?\s?PRIVATE KEY-----`)
+
+// pemNewlineUnescaper turns JSON string escapes inside a PEM block back into newlines
+var pemNewlineUnescaper = strings.NewReplacer(`\\n`, "\n", `\r\n`, "\n", `\n`, "\n", `\r`, "")
+
+// privateKeyInfo describes a parsed private key
+type privateKeyInfo struct {
+	kind      string // e.g. "RSA 2048-bit", "ECDSA P-256", "Ed25519"
+	encrypted bool
+	complete  bool // False when only the BEGIN header was found
+}
+
+// scanPrivateKeys detects PEM private keys, capturing the full block so the key type
+// and size can be reported. Unencrypted keys are critical; encrypted ones still need
+// their passphrase and are reported at medium severity.
+func (s *SecretScanner) scanPrivateKeys(data string, location string) []SecretMatch {
+	if !strings.Contains(data, "PRIVATE KEY-----") {
+		return nil
+	}
+
+	var matches []SecretMatch
+	for _, match := range privateKeyPattern.FindAllString(data, -1) {
+		// Normalize escaped newlines so raw and string-encoded copies deduplicate
+		key := strings.ReplaceAll(pemNewlineUnescaper.Replace(match), `\/`, "/")
+		info := parsePrivateKey(key)
+
+		header, _, _ := strings.Cut(key, "\n")
+		value := header + " [truncated]"
+		description := "Private Key (BEGIN header only, key body not found)"
+		severity := "high"
+
+		if info.complete {
+			value = fmt.Sprintf("%s [%d bytes]", header, len(key))
+			severity = "critical"
+			description = info.kind + " Private Key"
+			if info.encrypted {
+				severity = "medium"
+				description = "Encrypted " + description + " (passphrase required)"
+			}
+		}
+
+		matches = append(matches, SecretMatch{
+			Type:        "Private Key",
+			Value:       value,
+			RawValue:    key,
+			Location:    location,
+			FullPath:    location,
+			Description: description,
+			Severity:    severity,
+		})
+	}
+
+	return matches
+}
+
+// parsePrivateKey identifies the type, size and encryption of a PEM private key
+func parsePrivateKey(key string) privateKeyInfo {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return privateKeyInfo{}
+	}
+
+	info := privateKeyInfo{complete: true, kind: "Unparseable"}
+
+	switch block.Type {
+	case "ENCRYPTED PRIVATE KEY":
+		info.kind = "PKCS#8"
+		info.encrypted = true
+	case "OPENSSH PRIVATE KEY":
+		info.kind, info.encrypted = parseOpenSSHKey(block.Bytes)
+	case "RSA PRIVATE KEY", "EC PRIVATE KEY", "DSA PRIVATE KEY":
+		if block.Headers["Proc-Type"] == "4,ENCRYPTED" {
+			info.kind = strings.TrimSuffix(block.Type, " PRIVATE KEY")
+			info.encrypted = true
+			break
+		}
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			if k, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+				info.kind = fmt.Sprintf("RSA %d-bit", k.N.BitLen())
+			}
+		case "EC PRIVATE KEY":
+			if k, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+				info.kind = "ECDSA " + k.Curve.Params().Name
+			}
+		case "DSA PRIVATE KEY":
+			info.kind = "DSA"
+		}
+	case "PRIVATE KEY":
+		k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			break
+		}
+		switch k := k.(type) {
+		case *rsa.PrivateKey:
+			info.kind = fmt.Sprintf("RSA %d-bit", k.N.BitLen())
+		case *ecdsa.PrivateKey:
+			info.kind = "ECDSA " + k.Curve.Params().Name
+		case ed25519.PrivateKey:
+			info.kind = "Ed25519"
+		}
+	}
+
+	return info
+}
+
+// parseOpenSSHKey reads the key type and cipher from an "openssh-key-v1" blob. Only the
+// unencrypted public part is needed, so no SSH library is required.
+func parseOpenSSHKey(blob []byte) (kind string, encrypted bool) {
+	const magic = "openssh-key-v1\x00"
+	if !strings.HasPrefix(string(blob), magic) {
+		return "OpenSSH (unparseable)", false
+	}
+	rest := blob[len(magic):]
+
+	readString := func() []byte {
+		if len(rest) < 4 {
+			return nil
+		}
+		n := binary.BigEndian.Uint32(rest)
+		if uint32(len(rest)-4) < n {
+			rest = nil
+			return nil
+		}
+		str := rest[4 : 4+n]
+		rest = rest[4+n:]
+		return str
+	}
+
+	cipher := string(readString())
+	readString() // KDF name
+	readString() // KDF options
+	if len(rest) < 4 {
+		return "OpenSSH", cipher != "none"
+	}
+	rest = rest[4:] // Number of keys
+
+	// The public key blob starts with its own type string, followed by the key fields
+	rest = readString()
+	keyType := string(readString())
+	kind = "OpenSSH " + keyType
+
+	if keyType == "ssh-rsa" {
+		readString() // Public exponent
+		if n := readString(); n != nil {
+			kind = fmt.Sprintf("OpenSSH RSA %d-bit", new(big.Int).SetBytes(n).BitLen())
+		}
+	}
+
+	return kind, cipher != "none"
+}
@@ -0,0 +1,127 @@
+package scanner
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+)
+
+// pypiToken builds a token around a macaroon naming pypi.org, like the ones PyPI issues
+func pypiToken() string {
+	macaroon := []byte("\x02\x01\x08pypi.org\x02\x25\x08\x01\x12\x10")
+	for c := byte('A'); c < 'A'+60; c++ {
+		macaroon = append(macaroon, c)
+	}
+	return "pypi-" + base64.RawURLEncoding.EncodeToString(macaroon)
+}
+
+func TestDetectPyPIToken(t *testing.T) {
+	token := pypiToken()
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"bare", `{"token": "` + token + `"}`},
+		{"pypirc", "[distutils]\nindex-servers =\n    pypi\n\n[pypi]\n  username = __token__\n  password = " + token + "\n"},
+		{"pypirc in a JSON string", `{"pypirc": "[pypi]\nusername = __token__\npassword = ` + token + `\n"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, _ := NewSecretScanner().ScanCollection(rawRequest("https://upload.pypi.org/legacy/", tt.body))
+			var found []SecretMatch
+			for _, match := range matches {
+				if match.Type == "PyPI API Token" {
+					found = append(found, match)
+				}
+			}
+			if len(found) != 1 || found[0].RawValue != token {
+				t.Errorf("PyPI matches = %+v, want the token once", found)
+			}
+		})
+	}
+}
+
+func TestVerifyPyPI(t *testing.T) {
+	token := pypiToken()
+
+	tests := []struct {
+		name        string
+		token       string
+		uploadCheck bool
+		status      int
+		wantStatus  VerificationStatus
+		wantNote    string
+		wantProbe   bool
+	}{
+		{
+			name:       "structure only by default",
+			token:      token,
+			wantStatus: StatusUnsupported,
+			wantNote:   "Valid PyPI macaroon, upload check disabled (deep_scan.verify_pypi_upload)",
+		},
+		{
+			name:        "malformed macaroon",
+			token:       "pypi-AgEIcHlwaS5vcmc" + "!!!!notbase64!!!!notbase64!!!!notbase64!!!!notbase64",
+			uploadCheck: true,
+			wantStatus:  StatusInvalid,
+			wantNote:    "Malformed PyPI macaroon",
+		},
+		{
+			name:        "accepted",
+			token:       token,
+			uploadCheck: true,
+			status:      400,
+			wantStatus:  StatusActive,
+			wantNote:    "Token accepted by the PyPI upload endpoint",
+			wantProbe:   true,
+		},
+		{
+			name:        "rejected",
+			token:       token,
+			uploadCheck: true,
+			status:      403,
+			wantStatus:  StatusInvalid,
+			wantNote:    "Token rejected by PyPI",
+			wantProbe:   true,
+		},
+		{
+			name:        "rate limited",
+			token:       token,
+			uploadCheck: true,
+			status:      429,
+			wantStatus:  StatusRateLimited,
+			wantProbe:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newCountingServer(t, true, func(w http.ResponseWriter, r *http.Request) {
+				user, password, _ := r.BasicAuth()
+				if r.Method != "POST" || r.URL.Path != "/legacy/" || user != "__token__" || password != tt.token {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				if r.FormValue(":action") != "file_upload" || r.FormValue("content") != "" {
+					t.Errorf("probe form = %v, want an empty upload", r.Form)
+				}
+				w.WriteHeader(tt.status)
+			})
+			v := newTestVerifier(t)
+			v.SetRetry(1, 0)
+			v.SetPyPIUploadCheck(tt.uploadCheck)
+			routeTo(t, v, server)
+
+			result := v.VerifySecret(context.Background(), SecretMatch{Type: "PyPI API Token", RawValue: tt.token})
+			if result.Status != tt.wantStatus {
+				t.Fatalf("status = %s (%s), want %s", result.Status, result.Note, tt.wantStatus)
+			}
+			if result.Note != tt.wantNote {
+				t.Errorf("note = %q, want %q", result.Note, tt.wantNote)
+			}
+			if probed := len(server.requested()) > 0; probed != tt.wantProbe {
+				t.Errorf("upload endpoint probed = %t, want %t", probed, tt.wantProbe)
+			}
+		})
+	}
+}
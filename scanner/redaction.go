@@ -0,0 +1,99 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// RedactionPolicy controls how much of a secret value an output shows
+type RedactionPolicy string
+
+const (
+	RedactNone    RedactionPolicy = "none"    // Full raw value
+	RedactPartial RedactionPolicy = "partial" // First and last 4 characters
+	RedactFull    RedactionPolicy = "full"    // Type and SHA-256 fingerprint only
+)
+
+// fingerprintLength is how many hex characters of the SHA-256 fingerprint are shown
+const fingerprintLength = 12
+
+// redactionStrictness ranks policies from showing the most of a value to the least
+var redactionStrictness = map[RedactionPolicy]int{RedactNone: 0, RedactPartial: 1, RedactFull: 2}
+
+// AtLeastAsStrictAs reports whether the policy shows no more of a value than other does
+func (p RedactionPolicy) AtLeastAsStrictAs(other RedactionPolicy) bool {
+	return redactionStrictness[p] >= redactionStrictness[other]
+}
+
+// ParseRedactionPolicy parses a policy name; an empty name yields the given default
+func ParseRedactionPolicy(name string, defaultPolicy RedactionPolicy) (RedactionPolicy, error) {
+	switch policy := RedactionPolicy(strings.ToLower(strings.TrimSpace(name))); policy {
+	case "":
+		return defaultPolicy, nil
+	case RedactNone, RedactPartial, RedactFull:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown redaction policy %q (use none, partial or full)", name)
+	}
+}
+
+// Redact renders a secret's value under the policy
+func (p RedactionPolicy) Redact(secret SecretMatch) string {
+	switch p {
+	case RedactNone:
+		return secret.RawValue
+	case RedactFull:
+		return fmt.Sprintf("[%s sha256:%s]", secret.Type, Fingerprint(secret.RawValue)[:fingerprintLength])
+	default:
+		// Prefer the scanner's masking, which keeps e.g. a connection string's user and host
+		if secret.Value != "" && secret.Value != secret.RawValue {
+			return secret.Value
+		}
+		return redactPartial(secret.RawValue)
+	}
+}
+
+// LooksRedacted reports whether a value has the form Redact gives it under the partial
+// or full policy, for reports written before they recorded their policy
+func LooksRedacted(value string) bool {
+	if strings.HasPrefix(value, "[") && strings.Contains(value, " sha256:") && strings.HasSuffix(value, "]") {
+		return true
+	}
+	return strings.Contains(value, "****")
+}
+
+// Snippet renders a secret's context snippet with the value shown under the policy
+func (p RedactionPolicy) Snippet(secret SecretMatch) string {
+	if secret.ContextSnippet == "" {
+		return ""
+	}
+	return strings.Replace(secret.ContextSnippet, secret.Value, p.Redact(secret), 1)
+}
+
+// Fingerprint returns the hex SHA-256 of a raw secret value, so redacted duplicates can
+// still be correlated
+func Fingerprint(rawValue string) string {
+	sum := sha256.Sum256([]byte(rawValue))
+	return hex.EncodeToString(sum[:])
+}
+
+// redactPartial keeps the first and last 4 characters of a secret (2 for short ones)
+func redactPartial(secret string) string {
+	if len(secret) <= 8 {
+		return "****"
+	}
+
+	// Show first 4 and last 4 characters
+	visible := 4
+	if len(secret) < 12 {
+		visible = 2
+	}
+
+	start := secret[:visible]
+	end := secret[len(secret)-visible:]
+	middle := strings.Repeat("*", min(20, len(secret)-2*visible))
+
+	return start + middle + end
+}
@@ -0,0 +1,221 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/yourusername/postman-observer/retryafter"
+)
+
+// Defaults for timing out and retrying verification requests
+const (
+	defaultVerifyRequestTimeout = 10 * time.Second // Per attempt
+	defaultVerifyAttempts       = 3
+	defaultVerifyRetryDelay     = 500 * time.Millisecond
+)
+
+// retryClient is an http.Client whose Do retries network errors, HTTP 429 and 5xx
+// responses with exponential backoff and jitter, waiting at least as long as a
+// Retry-After header asks. It gives up early rather than wait past the request's context
+// deadline, returning the last response so callers still see e.g. the 429. Every attempt
+// first waits for its turn with the host's pacer.
+type retryClient struct {
+	*http.Client
+	pacer       *hostPacer
+	maxAttempts int
+	baseDelay   time.Duration
+}
+
+// attemptsKey is the context key of the counter Do adds each attempt to
+type attemptsKey struct{}
+
+// withAttemptCounter returns a context whose requests count their attempts in *attempts
+func withAttemptCounter(ctx context.Context, attempts *int) context.Context {
+	return context.WithValue(ctx, attemptsKey{}, attempts)
+}
+
+func (c *retryClient) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	attempts, _ := ctx.Value(attemptsKey{}).(*int)
+	if err := recordRequest(ctx, req.URL); err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempts != nil {
+			*attempts = attempt
+		}
+
+		if err := c.pacer.wait(ctx, req.URL.Host); err != nil {
+			return nil, err
+		}
+		resp, err := c.Client.Do(req)
+		if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusForbidden) {
+			noteRateLimitReset(ctx, resp.Header)
+		}
+		if attempt >= c.maxAttempts || !retryable(resp, err) || ctx.Err() != nil {
+			return resp, err
+		}
+		// A request body can only be sent again if it can be rewound
+		if req.Body != nil && req.GetBody == nil {
+			return resp, err
+		}
+
+		wait := c.backoff(attempt)
+		if resp != nil {
+			if retryAfter, _ := retryafter.Parse(resp.Header.Get("Retry-After")); retryAfter > wait {
+				wait = retryAfter
+			}
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return resp, err
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+// dial opens a TCP connection for a connectivity check, retrying failed dials like Do
+// retries requests: up to maxAttempts, each limited to the client's timeout, with the
+// same backoff and never past the context's deadline. Only addresses allowed approves
+// are connected to; others fail with errPrivateAddress and aren't retried.
+func (c *retryClient) dial(ctx context.Context, address string, allowed func(net.IP) bool) (net.Conn, error) {
+	attempts, _ := ctx.Value(attemptsKey{}).(*int)
+	dialer := net.Dialer{Timeout: c.Timeout, Control: allowedAddressesOnly(allowed)}
+
+	for attempt := 1; ; attempt++ {
+		if attempts != nil {
+			*attempts = attempt
+		}
+
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err == nil || attempt >= c.maxAttempts || ctx.Err() != nil || errors.Is(err, errPrivateAddress) {
+			return conn, err
+		}
+
+		wait := c.backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return nil, err
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		}
+	}
+}
+
+// backoff is the wait before retrying after the given attempt: the base delay doubled for
+// each earlier attempt, with +/-50% jitter so concurrent retries don't line up
+func (c *retryClient) backoff(attempt int) time.Duration {
+	delay := c.baseDelay << (attempt - 1)
+	return delay/2 + time.Duration(rand.Int63n(int64(delay)+1))
+}
+
+// retryable reports whether a request failed in a way that may succeed if sent again
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, errPrivateAddress) // Refused, and would be again
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// rateLimitReset reads when a provider's rate limit resets from Retry-After or, as GitHub
+// sends it, X-RateLimit-Reset (a Unix time, or seconds from now); zero when neither is set
+func rateLimitReset(header http.Header) time.Time {
+	if retryAfter, _ := retryafter.Parse(header.Get("Retry-After")); retryAfter > 0 {
+		return time.Now().Add(retryAfter)
+	}
+	reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil || reset <= 0 {
+		return time.Time{}
+	}
+	if reset < 1e9 { // Too small to be a Unix time
+		return time.Now().Add(time.Duration(reset) * time.Second)
+	}
+	return time.Unix(reset, 0)
+}
+
+// noteRateLimitReset records in the request trace when a rate-limiting response says to
+// come back, keeping the latest time if several responses say so
+func noteRateLimitReset(ctx context.Context, header http.Header) {
+	trace, _ := ctx.Value(traceKey{}).(*requestTrace)
+	reset := rateLimitReset(header)
+	if trace == nil || reset.IsZero() {
+		return
+	}
+
+	trace.mu.Lock()
+	defer trace.mu.Unlock()
+	if reset.After(trace.retryAt) {
+		trace.retryAt = reset
+	}
+}
+
+// SetRetry sets how many times a verification request is attempted (1 never retries) and
+// the delay before the first retry, doubled for each one after
+func (v *SecretVerifier) SetRetry(maxAttempts int, baseDelay time.Duration) {
+	if maxAttempts > 0 {
+		v.httpClient.maxAttempts = maxAttempts
+	}
+	if baseDelay > 0 {
+		v.httpClient.baseDelay = baseDelay
+	}
+}
+
+// SetTimeout sets how long one verification request may take (default 10s). A secret's
+// retries share a budget of three times that.
+func (v *SecretVerifier) SetTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		v.httpClient.Timeout = timeout
+	}
+}
+
+// verifyBudget is how long verifying one secret may take, retries included
+func (v *SecretVerifier) verifyBudget() time.Duration {
+	return 3 * v.httpClient.Timeout
+}
+
+// requestFailed is the result of a verification request that got no response. Timeouts
+// get their own status, so a slow network isn't mistaken for a dead secret.
+func requestFailed(err error) *VerificationResult {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return &VerificationResult{Status: StatusTimeout, Note: "No response within the timeout", VerifiedAt: time.Now()}
+	}
+
+	// The URL is left out: a few providers take the secret as a query parameter
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	return &VerificationResult{Status: StatusError, Note: "Request failed: " + err.Error(), VerifiedAt: time.Now()}
+}
@@ -0,0 +1,58 @@
+package scanner
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestVerifyRetries(t *testing.T) {
+	const key = "3f2b1c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"
+
+	tests := []struct {
+		name            string
+		statuses        []int // Answer to each request in turn; the last one repeats
+		wantStatus      VerificationStatus
+		wantAttempts    int
+		wantRateLimited bool
+	}{
+		{"fails twice, then succeeds", []int{503, 429, 200}, StatusActive, 3, false},
+		{"rate limited to the end", []int{429}, StatusRateLimited, 3, true},
+		{"server errors to the end", []int{502}, StatusError, 3, false},
+		{"rejection is not retried", []int{401, 200}, StatusInvalid, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			requests := 0
+			server := newCountingServer(t, true, func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				status := tt.statuses[min(requests, len(tt.statuses)-1)]
+				requests++
+				mu.Unlock()
+
+				if status == http.StatusTooManyRequests {
+					w.Header().Set("Retry-After", "0")
+				}
+				w.WriteHeader(status)
+				if status == http.StatusOK {
+					w.Write([]byte(`{"id": "01234567-89ab-cdef-0123-456789abcdef", "email": "ops@example.com"}`))
+				}
+			})
+			v := newTestVerifier(t)
+			routeTo(t, v, server)
+
+			result := v.VerifySecret(context.Background(), SecretMatch{Type: "Heroku API Key", RawValue: key})
+			if result.Status != tt.wantStatus {
+				t.Fatalf("status = %s (%s), want %s", result.Status, result.Note, tt.wantStatus)
+			}
+			if result.Attempts != tt.wantAttempts || len(server.requested()) != tt.wantAttempts {
+				t.Errorf("attempts = %d (%d requests), want %d", result.Attempts, len(server.requested()), tt.wantAttempts)
+			}
+			if result.RateLimited != tt.wantRateLimited {
+				t.Errorf("rate limited = %v, want %v", result.RateLimited, tt.wantRateLimited)
+			}
+		})
+	}
+}
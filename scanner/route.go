@@ -0,0 +1,59 @@
+package scanner
+
+import "strings"
+
+// routedTypes are the catch-all patterns whose value may well belong to a known provider,
+// e.g. a ghp_ token sent as "Authorization: Bearer ghp_..."
+var routedTypes = map[string]bool{
+	"Bearer Token":    true,
+	"Generic API Key": true,
+	"Generic Secret":  true,
+}
+
+// providerPrefixes map the prefixes providers put on their tokens to the secret type that
+// is verified for them, longest prefixes first where they overlap
+var providerPrefixes = []struct {
+	prefix     string
+	secretType string
+}{
+	{"github_pat_", "GitHub OAuth"},
+	{"ghp_", "GitHub OAuth"},
+	{"gho_", "GitHub OAuth"},
+	{"ghu_", "GitHub OAuth"},
+	{"ghs_", "GitHub OAuth"},
+	{"ghr_", "GitHub OAuth"},
+	{"glpat-", "GitLab Token"},
+	{"xoxb-", "Slack Token"},
+	{"xoxp-", "Slack Token"},
+	{"xoxa-", "Slack Token"},
+	{"xoxr-", "Slack Token"},
+	{"xoxs-", "Slack Token"},
+	{"sk_live_", "Stripe Secret Key"},
+	{"sk_test_", "Stripe Secret Key"},
+	{"rk_live_", "Stripe Restricted Key"},
+	{"rk_test_", "Stripe Restricted Key"},
+	{"SG.", "SendGrid API Key"},
+	{"AIza", "Google API Key"},
+	{"dop_v1_", "DigitalOcean Token"},
+	{"npm_", "npm Token"},
+	{"pypi-", "PyPI API Token"},
+	{"dckr_pat_", "Docker Hub Token"},
+	{"NRAK-", "New Relic User API Key"},
+	{"eyJ", "JWT Token"},
+}
+
+// routeSecret returns the secret type a Bearer or generic match is verified as when its
+// value starts with a known provider prefix. Other matches, and values no prefix
+// recognizes, keep their own type.
+func routeSecret(secret SecretMatch) (string, bool) {
+	if !routedTypes[secret.Type] {
+		return secret.Type, false
+	}
+	value := strings.TrimSpace(secret.RawValue)
+	for _, p := range providerPrefixes {
+		if strings.HasPrefix(value, p.prefix) && len(value) > len(p.prefix)+8 {
+			return p.secretType, true
+		}
+	}
+	return secret.Type, false
+}
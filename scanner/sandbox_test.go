@@ -0,0 +1,66 @@
+package scanner
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPayPalContextAndSandbox(t *testing.T) {
+	clientID := "A" + strings.Repeat("Qx7vL2mR9kT4pW8s", 5)[:79]
+	secret := "E" + strings.Repeat("Hn3bV6cJ1zF5yD0g", 5)[:79]
+
+	// request builds a collection with one request to url sending body
+	request := func(url, body string) map[string]interface{} {
+		return map[string]interface{}{"item": []interface{}{map[string]interface{}{
+			"name": "Token",
+			"request": map[string]interface{}{
+				"method": "POST",
+				"url":    url,
+				"body":   map[string]interface{}{"mode": "raw", "raw": body},
+			},
+		}}}
+	}
+
+	tests := []struct {
+		name         string
+		url          string
+		body         string
+		wantType     string
+		wantSeverity string // Empty: not found
+	}{
+		{"client_id without paypal", "https://auth.example.com/oauth/token", "client_id=" + clientID, "PayPal Client ID", ""},
+		{"client id near paypal", "https://auth.example.com/oauth/token", "paypal_client_id=" + clientID, "PayPal Client ID", "low"},
+		{"live secret", "https://api-m.paypal.com/v1/oauth2/token", "paypal_secret=" + secret, "PayPal Client Secret", "high"},
+		{"sandbox secret, sandbox in the url", "https://api-m.sandbox.paypal.com/v1/oauth2/token", "paypal_secret=" + secret, "PayPal Client Secret", "low"},
+		{"sandbox secret, sandbox next to it", "https://auth.example.com/token", "paypal_secret=" + secret + "\nbase=https://api-m.sandbox.paypal.com", "PayPal Client Secret", "low"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSecretScanner()
+			s.SetCollectionJSONScan(false)
+			matches, _ := s.ScanCollection(request(tt.url, tt.body))
+
+			var found *SecretMatch
+			for i := range matches {
+				if matches[i].Type == tt.wantType {
+					found = &matches[i]
+				}
+			}
+			if tt.wantSeverity == "" {
+				if found != nil {
+					t.Fatalf("found %s at %s, want nothing", found.Type, found.Location)
+				}
+				return
+			}
+			if found == nil {
+				t.Fatalf("no %s found (matches: %+v)", tt.wantType, matches)
+			}
+			if found.Severity != tt.wantSeverity {
+				t.Errorf("severity = %q, want %q", found.Severity, tt.wantSeverity)
+			}
+			if sandbox := strings.HasSuffix(found.Description, "(sandbox)"); sandbox != (tt.wantSeverity == "low" && tt.wantType == "PayPal Client Secret") {
+				t.Errorf("description %q, sandbox marked = %v", found.Description, sandbox)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package scanner
+
+import (
+	"sync"
+	"testing"
+)
+
+// environment builds an exported environment holding one variable per value
+func environment(values ...string) map[string]interface{} {
+	variables := make([]interface{}, len(values))
+	for i, value := range values {
+		variables[i] = map[string]interface{}{"key": "config", "value": value}
+	}
+	return map[string]interface{}{"values": variables}
+}
+
+func TestScanSkipCounts(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   SkipCounts
+	}{
+		{"nothing skipped", []string{"region=eu-west-1"}, SkipCounts{}},
+		{"placeholders", []string{"api_key=xxxxxxxxxxxxxxxxxxxx", "api_key=EXAMPLE_KEY_1234567890"}, SkipCounts{Placeholders: 2}},
+		{"low entropy", []string{"api_key=abcdabcdabcdabcdabcd"}, SkipCounts{LowEntropy: 1}},
+		{"both", []string{"api_key=xxxxxxxxxxxxxxxxxxxx", "api_key=abcdabcdabcdabcdabcd"}, SkipCounts{Placeholders: 1, LowEntropy: 1}},
+	}
+
+	// One scanner scanning every environment at once: each scan gets its own counts
+	s := NewSecretScanner()
+	var wg sync.WaitGroup
+	for round := 0; round < 20; round++ {
+		for _, tt := range tests {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, skipped := s.ScanEnvironment(environment(tt.values...))
+				if skipped != tt.want {
+					t.Errorf("%s: skipped %+v, want %+v", tt.name, skipped, tt.want)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+}
+
+func TestVariablesOnlyCollection(t *testing.T) {
+	// Every credential is a {{variable}} or :param, as in a well-kept collection
+	collection := map[string]interface{}{
+		"auth": map[string]interface{}{
+			"type":   "bearer",
+			"bearer": []interface{}{map[string]interface{}{"key": "token", "value": "{{access_token}}"}},
+		},
+		"variable": []interface{}{
+			map[string]interface{}{"key": "api_key", "value": "{{api_key}}"},
+			map[string]interface{}{"key": "base_url", "value": "https://api.example.com"},
+		},
+		"item": []interface{}{
+			map[string]interface{}{
+				"name": "Get account",
+				"request": map[string]interface{}{
+					"method": "GET",
+					"url":    "{{base_url}}/accounts/:accountId/keys/:apiKey?api_key={{api_key}}&token={{$guid}}",
+					"header": []interface{}{
+						map[string]interface{}{"key": "Authorization", "value": "Bearer {{access_token}}"},
+						map[string]interface{}{"key": "X-API-Key", "value": "{{api_key}}"},
+					},
+				},
+			},
+			map[string]interface{}{
+				"name": "Login",
+				"request": map[string]interface{}{
+					"method": "POST",
+					"url":    "{{base_url}}/oauth/token",
+					"body": map[string]interface{}{
+						"mode": "raw",
+						"raw":  `{"client_id": "{{client_id}}", "client_secret": "{{client_secret}}", "password": "{{password}}"}`,
+					},
+				},
+			},
+		},
+	}
+
+	s := NewSecretScanner()
+	s.SetCollectionJSONScan(true)
+	if matches, _ := s.ScanCollection(collection); len(matches) != 0 {
+		t.Errorf("found %d secrets in a collection of placeholders: %+v", len(matches), matches)
+	}
+}
@@ -0,0 +1,80 @@
+package scanner
+
+// VerificationStatus is the machine-readable outcome of verifying a secret
+type VerificationStatus string
+
+const (
+	StatusActive      VerificationStatus = "active"       // The provider accepted the secret
+	StatusInvalid     VerificationStatus = "invalid"      // The provider rejected it (revoked, wrong, unreachable host)
+	StatusExpired     VerificationStatus = "expired"      // It carries an expiry that has passed
+	StatusRateLimited VerificationStatus = "rate_limited" // The provider refused to answer for now
+	StatusUnsupported VerificationStatus = "unsupported"  // It can't be checked (no verifier, missing half of a pair)
+	StatusError       VerificationStatus = "error"        // The check itself failed (network, unexpected response)
+	StatusTimeout     VerificationStatus = "timeout"      // The provider didn't answer within the timeout
+	StatusSkipped     VerificationStatus = "skipped"      // The verification policy forbids checking it
+	StatusReachable   VerificationStatus = "reachable"    // Its host accepts connections; the credentials weren't tried
+	StatusRevoked     VerificationStatus = "revoked"      // The provider reports it revoked for good
+	StatusInactive    VerificationStatus = "inactive"     // Its user or app is deactivated; it works again if they are reactivated
+
+	StatusBudgetExceeded VerificationStatus = "budget_exceeded" // The run's verification budget ran out first
+)
+
+// statusLabels are the headlines of human-readable verification messages
+var statusLabels = map[VerificationStatus]string{
+	StatusActive:      "✅ ACTIVE",
+	StatusInvalid:     "❌ INVALID",
+	StatusExpired:     "⏰ EXPIRED",
+	StatusRateLimited: "⏸️  RATE LIMITED",
+	StatusUnsupported: "➖ NOT VERIFIABLE",
+	StatusError:       "⚠️  ERROR",
+	StatusTimeout:     "⌛ TIMED OUT",
+	StatusSkipped:     "⏭️  SKIPPED",
+	StatusReachable:   "🔌 REACHABLE",
+	StatusRevoked:     "🚫 REVOKED",
+	StatusInactive:    "💤 INACTIVE",
+
+	StatusBudgetExceeded: "⏭️  SKIPPED",
+}
+
+// defaultNotes explain a status when the verifier gave no note of its own
+var defaultNotes = map[VerificationStatus]string{
+	StatusRateLimited:    "Cannot verify at this time",
+	StatusBudgetExceeded: "budget exceeded",
+}
+
+// Label returns the status headline shown to humans, e.g. "✅ ACTIVE"
+func (s VerificationStatus) Label() string {
+	if label, ok := statusLabels[s]; ok {
+		return label
+	}
+	return statusLabels[StatusError]
+}
+
+// Inconclusive reports whether a check that ended with this status failed to learn
+// anything about the secret
+func (s VerificationStatus) Inconclusive() bool {
+	return s == StatusRateLimited || s == StatusTimeout || s == StatusError || s == StatusBudgetExceeded
+}
+
+// finish fills in the fields derived from the status - the IsValid, RateLimited,
+// SkippedByPolicy and Inconclusive flags and the human-readable Message - so they never
+// disagree with it
+func (r *VerificationResult) finish() *VerificationResult {
+	if r.Status == "" {
+		r.Status = StatusError
+	}
+	r.IsValid = r.Status == StatusActive
+	r.RateLimited = r.Status == StatusRateLimited
+	r.SkippedByPolicy = r.Status == StatusSkipped
+	r.Inconclusive = r.Status.Inconclusive()
+
+	note := r.Note
+	if note == "" {
+		note = defaultNotes[r.Status]
+	}
+	r.Message = r.Status.Label()
+	if note != "" {
+		r.Message += " - " + note
+	}
+	return r
+}
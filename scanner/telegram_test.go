@@ -0,0 +1,108 @@
+package scanner
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+const telegramToken = "7204518836:AAHk3vQ9mZ2xLp8RtN5wYc1JdF6sGbE4uKo"
+
+func TestDetectTelegramToken(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		body string
+		want bool
+	}{
+		{"bot token field", "https://example.com/notify", `{"bot_token": "` + telegramToken + `"}`, true},
+		{"telegram config", "https://example.com/notify", `{"telegram": {"token": "` + telegramToken + `"}}`, true},
+		{"no context", "https://example.com/orders", `{"ref": "` + telegramToken + `"}`, false},
+		{"digits and text", "https://example.com/orders", `{"range": "20240101:AAPL", "note": "1700000000:AA batch settled"}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, _ := NewSecretScanner().ScanCollection(rawRequest(tt.url, tt.body))
+			found := false
+			for _, match := range matches {
+				if match.Type == "Telegram Bot Token" {
+					found = true
+					if match.RawValue != telegramToken {
+						t.Errorf("RawValue = %q, want %q", match.RawValue, telegramToken)
+					}
+				}
+			}
+			if found != tt.want {
+				t.Errorf("Telegram token found = %t, want %t", found, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyTelegram(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantStatus  VerificationStatus
+		wantNote    string
+		wantDetails map[string]string
+	}{
+		{
+			name:        "active",
+			status:      200,
+			body:        `{"ok": true, "result": {"id": 7204518836, "is_bot": true, "first_name": "Alerts", "username": "acme_alerts_bot"}}`,
+			wantStatus:  StatusActive,
+			wantNote:    "Bot @acme_alerts_bot",
+			wantDetails: map[string]string{"login": "acme_alerts_bot"},
+		},
+		{
+			name:       "invalid",
+			status:     401,
+			body:       `{"ok": false, "error_code": 401, "description": "Unauthorized"}`,
+			wantStatus: StatusInvalid,
+			wantNote:   "Unauthorized",
+		},
+		{
+			name:       "rate limited",
+			status:     429,
+			body:       `{"ok": false, "error_code": 429, "description": "Too Many Requests: retry after 5", "parameters": {"retry_after": 5}}`,
+			wantStatus: StatusRateLimited,
+		},
+		{
+			name:       "not JSON",
+			status:     502,
+			body:       `<html>Bad Gateway</html>`,
+			wantStatus: StatusError,
+			wantNote:   "Invalid response",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newCountingServer(t, true, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/bot"+telegramToken+"/getMe" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+			v := newTestVerifier(t)
+			v.SetRetry(1, 0)
+			routeTo(t, v, server)
+
+			result := v.VerifySecret(context.Background(), SecretMatch{Type: "Telegram Bot Token", RawValue: telegramToken})
+			if result.Status != tt.wantStatus {
+				t.Fatalf("status = %s (%s), want %s", result.Status, result.Note, tt.wantStatus)
+			}
+			if result.Note != tt.wantNote {
+				t.Errorf("note = %q, want %q", result.Note, tt.wantNote)
+			}
+			for key, want := range tt.wantDetails {
+				if result.Details[key] != want {
+					t.Errorf("details[%s] = %q, want %q", key, result.Details[key], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package scanner
+
+import "testing"
+
+func TestPairTwilioCredentials(t *testing.T) {
+	const (
+		sid   = "AC3f9a1c7e5b2d8f4a6c0e9b3d7f1a5c2e"
+		token = "8e2b6d0f4a9c3e7b1d5f8a2c6e0b4d9f"
+	)
+	header := func(key, value string) map[string]interface{} {
+		return map[string]interface{}{"key": key, "value": value}
+	}
+	request := func(headers []interface{}, extra map[string]interface{}) map[string]interface{} {
+		request := map[string]interface{}{"method": "POST", "url": "https://api.twilio.com/2010-04-01/Accounts/" + sid + "/Messages.json", "header": headers}
+		for k, v := range extra {
+			request[k] = v
+		}
+		return map[string]interface{}{"name": "Send SMS", "request": request}
+	}
+
+	tests := []struct {
+		name       string
+		collection map[string]interface{}
+		wantPaired bool
+	}{
+		{
+			"auth_token variable",
+			map[string]interface{}{
+				"variable": []interface{}{header("account_sid", sid), header("auth_token", token)},
+				"item":     []interface{}{request(nil, nil)},
+			},
+			true,
+		},
+		{
+			"TWILIO_AUTH_TOKEN in a script",
+			map[string]interface{}{"item": []interface{}{request(nil, map[string]interface{}{
+				"body": map[string]interface{}{"mode": "raw", "raw": "TWILIO_ACCOUNT_SID=" + sid + "\nTWILIO_AUTH_TOKEN=" + token},
+			})}},
+			true,
+		},
+		{
+			"Basic auth with the SID as user",
+			map[string]interface{}{"item": []interface{}{request(nil, map[string]interface{}{
+				"auth": map[string]interface{}{"type": "basic", "basic": []interface{}{
+					map[string]interface{}{"key": "password", "value": token, "type": "string"},
+					map[string]interface{}{"key": "username", "value": sid, "type": "string"},
+				}},
+			})}},
+			true,
+		},
+		{
+			"credentials in the URL",
+			map[string]interface{}{"item": []interface{}{map[string]interface{}{"name": "Send SMS", "request": map[string]interface{}{
+				"method": "POST",
+				"url":    "https://" + sid + ":" + token + "@api.twilio.com/2010-04-01/Accounts/" + sid + "/Messages.json",
+			}}}},
+			true,
+		},
+		{
+			"hashes next to the SID",
+			map[string]interface{}{"item": []interface{}{request([]interface{}{
+				header("If-None-Match", `"d41d8cd98f00b204e9800998ecf8427e"`),
+				header("X-Request-Id", "5f2c8a1e9b3d7f4a6c0e2b8d1f5a9c3e"),
+				header("Content-MD5", "9e107d9d372bb6826bd81d3542a419d6"),
+			}, map[string]interface{}{
+				"body": map[string]interface{}{"mode": "raw", "raw": `{"checksum": "e4d909c290d0fb1ca068ffaddf22cbd0", "account": "` + sid + `"}`},
+			})}},
+			false,
+		},
+		{
+			"Basic auth with another user",
+			map[string]interface{}{"item": []interface{}{request(nil, map[string]interface{}{
+				"auth": map[string]interface{}{"type": "basic", "basic": []interface{}{
+					map[string]interface{}{"key": "username", "value": "deploy", "type": "string"},
+					map[string]interface{}{"key": "password", "value": token, "type": "string"},
+				}},
+			})}},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, _ := NewSecretScanner().ScanCollection(tt.collection)
+
+			var paired []string
+			for _, match := range matches {
+				if match.Type == "Twilio Credentials" {
+					paired = append(paired, match.RawValue)
+				}
+			}
+			if !tt.wantPaired {
+				if len(paired) != 0 {
+					t.Errorf("paired %q, want no Twilio credentials", paired)
+				}
+				return
+			}
+			if len(paired) != 1 || paired[0] != sid+":"+token {
+				t.Errorf("paired %q, want the SID with its auth token", paired)
+			}
+		})
+	}
+}
@@ -2,66 +2,462 @@ package scanner
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"net"
 	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/yourusername/postman-observer/metrics"
 )
 
-// VerificationResult represents the result of verifying a secret
+// VerificationResult represents the result of verifying a secret. Verifiers set Status,
+// Note and Details; IsValid, RateLimited, SkippedByPolicy, Inconclusive and Message are
+// derived from them.
 type VerificationResult struct {
-	IsValid     bool
-	Message     string
+	Status  VerificationStatus
+	Note    string // What the provider said, without the status, e.g. "Bot @example"
+	IsValid bool
+	Message string // Status label and note for humans, e.g. "✅ ACTIVE - Bot @example"
+
 	StatusCode  int
 	VerifiedAt  time.Time
 	RateLimited bool
+
+	// SkippedByPolicy marks a secret that was deliberately not verified (see VerifyPolicy)
+	SkippedByPolicy bool
+
+	// Inconclusive marks a check that says nothing about the secret (rate limited, timed out
+	// or failed), so it may well be live and must not be treated as invalid
+	Inconclusive bool
+
+	// Attempts is how many requests the last check of the secret took, retries included
+	Attempts int
+
+	// ExpiresAt, when set, is when the secret stops working on its own, e.g. a JWT's exp;
+	// a cached result is not reused past it
+	ExpiresAt time.Time
+
+	// RetryAt is when a provider that rate limited the check said to try again (from
+	// Retry-After or X-RateLimit-Reset); zero if it didn't say
+	RetryAt time.Time
+
+	// Severity, when set, replaces the secret's severity with what verification revealed,
+	// e.g. "critical" for a Firebase database anyone can read
+	Severity string
+
+	// Details holds what the provider told us about the secret, e.g. a GitHub token's
+	// login, scopes and token type
+	Details map[string]string
+
+	// Type, when set, is the provider type a Bearer or generic match was verified as, e.g.
+	// "GitHub OAuth" for "Bearer ghp_..."; it replaces the secret's type in reports
+	Type string
+}
+
+// clone returns a copy of the result, Details included, that can be changed on its own
+func (r *VerificationResult) clone() *VerificationResult {
+	copied := *r
+	copied.Details = maps.Clone(r.Details)
+	return &copied
 }
 
+// Defaults for VerifyAll: secrets verified at once, and the minimum spacing of requests to
+// the same provider host
+const (
+	defaultVerifyWorkers = 4
+	providerInterval     = time.Second
+)
+
 // SecretVerifier handles verification of discovered secrets
 type SecretVerifier struct {
-	httpClient *http.Client
+	httpClient *retryClient
+
+	mu        sync.Mutex
+	cache     map[string]*VerificationResult // Keyed by cacheKey
+	cacheTTL  time.Duration                  // How long results are reused (0 re-checks every secret)
+	cacheFile string                         // Where the cache is persisted ("" keeps it in memory)
+	jwks      map[string]*jwkSet             // JWT issuers' signing keys, keyed by issuer URL
+
+	issuerTransport http.RoundTripper // Fetches issuer keys from public addresses only
+	addressAllowed  func(net.IP) bool // Addresses untrusted hosts may resolve to (publicAddress)
+
+	connectivityChecks bool         // Dial database hosts to see if they are reachable
+	pypiUploadCheck    bool         // Probe PyPI's upload endpoint with PyPI tokens
+	workers            int          // Secrets VerifyAll verifies concurrently
+	policy             VerifyPolicy // Secret types that may be verified (nil allows all)
+
+	custom map[string]*CustomVerifier // Custom verifiers, keyed by the secret type they verify
+
+	proxyRequired bool   // Refuse to verify unless requests can go through the proxy
+	proxyAddr     string // host:port of that proxy ("" when none is configured)
+
+	budget runBudget // Verification allowed per run (unlimited by default)
+
+	audit     AuditHook // Receives an entry per verification (nil when auditing is off)
+	dryVerify bool      // Record what would be verified without sending any request
 }
 
 // NewSecretVerifier creates a new secret verifier
 func NewSecretVerifier() *SecretVerifier {
 	return &SecretVerifier{
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-			// Don't follow redirects for verification
-			CheckRedirect: func(req *http.Request, via []*http.Request) error {
-				return http.ErrUseLastResponse
+		httpClient: &retryClient{
+			Client: &http.Client{
+				Timeout: defaultVerifyRequestTimeout, // Per attempt; see verifyBudget
+				// Don't follow redirects for verification
+				CheckRedirect: func(req *http.Request, via []*http.Request) error {
+					return http.ErrUseLastResponse
+				},
+			},
+			pacer: &hostPacer{
+				interval: providerInterval,
+				slots:    make(map[string]time.Time),
 			},
+			maxAttempts: defaultVerifyAttempts,
+			baseDelay:   defaultVerifyRetryDelay,
 		},
+		cache: make(map[string]*VerificationResult),
+		jwks:  make(map[string]*jwkSet),
+
+		addressAllowed: publicAddress,
+		cacheTTL:       defaultVerificationCacheTTL,
+		workers:        defaultVerifyWorkers,
+		budget:         runBudget{started: time.Now()},
+	}
+}
+
+// SetWorkers sets how many secrets VerifyAll verifies concurrently (default 4)
+func (v *SecretVerifier) SetWorkers(n int) {
+	if n > 0 {
+		v.workers = n
+	}
+}
+
+// VerifyAll verifies secrets on a bounded worker pool and returns their results by index.
+// Identical secrets (same type, host and value) are verified once and share the result.
+// Requests to one provider host are still spaced out (see hostPacer). Rate-limited
+// secrets are retried once after the rest, outside the run budget they were already
+// counted against; secrets not reached before ctx is cancelled get a nil result.
+func (v *SecretVerifier) VerifyAll(ctx context.Context, secrets []SecretMatch) []*VerificationResult {
+	results := make([]*VerificationResult, len(secrets))
+
+	// Without this, copies of one secret would all miss the cache together and be sent
+	// to the provider side by side
+	owner := make([]int, len(secrets)) // Index of the first identical secret
+	first := make(map[string]int, len(secrets))
+	var unique []int
+	for i, secret := range secrets {
+		secret.Type, _ = routeSecret(secret)
+		key := cacheKey(secret)
+		if j, ok := first[key]; ok {
+			owner[i] = j
+			continue
+		}
+		first[key], owner[i] = i, i
+		unique = append(unique, i)
+	}
+
+	slots := make(chan struct{}, v.workers)
+	var wg sync.WaitGroup
+
+	for _, i := range unique {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			results[i] = v.VerifySecret(ctx, secrets[i])
+		}()
+	}
+	wg.Wait()
+
+	for _, i := range unique {
+		if ctx.Err() != nil {
+			break
+		}
+		if result := results[i]; result != nil && result.RateLimited {
+			results[i] = v.VerifySecret(OutsideRunBudget(ctx), secrets[i])
+		}
+	}
+
+	for i := range results {
+		results[i] = results[owner[i]]
+	}
+	return results
+}
+
+// hostPacer spaces out requests to the same host, so concurrent verification doesn't
+// hammer one provider (and get the scanner's IP banned)
+type hostPacer struct {
+	interval time.Duration
+
+	mu    sync.Mutex
+	slots map[string]time.Time // Earliest start of the next request to each host
+}
+
+// wait blocks until a request to host may start. It runs before the request's own
+// timeout starts, so time spent queued is never mistaken for a slow provider.
+func (p *hostPacer) wait(ctx context.Context, host string) error {
+	p.mu.Lock()
+	slot := p.slots[host]
+	if now := time.Now(); slot.Before(now) {
+		slot = now
+	}
+	p.slots[host] = slot.Add(p.interval)
+	p.mu.Unlock()
+
+	if wait := time.Until(slot); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// SetTransport sends verification requests through transport (e.g. one with a proxy)
+// instead of http.DefaultTransport; requests to one host are still spaced out
+func (v *SecretVerifier) SetTransport(transport http.RoundTripper) {
+	v.httpClient.Transport = transport
+
+	v.mu.Lock()
+	v.issuerTransport = nil // Rebuilt on top of the new transport
+	v.mu.Unlock()
+}
+
+// RequireProxy makes CheckProxy fail unless the proxy at addr (host:port, "" when none is
+// configured) accepts connections, so probes never go out directly
+func (v *SecretVerifier) RequireProxy(addr string) {
+	v.proxyRequired = true
+	v.proxyAddr = addr
+}
+
+// CheckProxy reports whether secrets may be verified now: always, unless a proxy is
+// required and it is missing or unreachable
+func (v *SecretVerifier) CheckProxy(ctx context.Context) error {
+	if !v.proxyRequired {
+		return nil
+	}
+	if v.proxyAddr == "" {
+		return fmt.Errorf("a verification proxy is required but none is configured")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", v.proxyAddr)
+	if err != nil {
+		return fmt.Errorf("verification proxy %s is unreachable: %w", v.proxyAddr, err)
+	}
+	conn.Close()
+	return nil
+}
+
+// SetConnectivityChecks enables or disables TCP reachability checks for connection strings
+func (v *SecretVerifier) SetConnectivityChecks(enabled bool) {
+	v.connectivityChecks = enabled
+}
+
+// SetPyPIUploadCheck enables or disables the live check of PyPI tokens against the upload
+// endpoint; without it, only the token's macaroon is validated
+func (v *SecretVerifier) SetPyPIUploadCheck(enabled bool) {
+	v.pypiUploadCheck = enabled
+}
+
+// VerifySecret attempts to verify if a secret is active. A secret that was already
+// verified within the cache TTL gets the earlier result without a new API call, and one
+// the verification policy forbids gets a skipped result without any call. Bearer and
+// generic matches carrying a known provider's token are verified as that provider's type,
+// which the result's Type reports. Once the run budget is spent, secrets get a
+// budget_exceeded result; cancelling ctx aborts the check.
+func (v *SecretVerifier) VerifySecret(ctx context.Context, secret SecretMatch) *VerificationResult {
+	secretType, routed := routeSecret(secret)
+	secret.Type = secretType
+	retype := func(result *VerificationResult) *VerificationResult {
+		if routed {
+			result.Type = secretType
+		}
+		return result
+	}
+
+	if !v.policy.Allows(secret.Type) {
+		return retype(policyResult(secret))
+	}
+
+	if v.dryVerify {
+		v.verify(ctx, secret, true) // Only audits the request the verifier would send
+		return retype(dryVerifyResult())
+	}
+
+	key := cacheKey(secret)
+
+	v.mu.Lock()
+	cached, ok := v.cache[key]
+	v.mu.Unlock()
+	if ok && v.fresh(cached) {
+		return retype(cached.clone()) // Callers may change what they get; the cache must not
 	}
+
+	if !outsideBudget(ctx) && !v.budget.take() {
+		return retype(budgetResult())
+	}
+
+	result := retype(v.verify(ctx, secret, false))
+	if result.IsValid {
+		metrics.SecretsVerifiedActive.Inc(secret.Type)
+	}
+
+	// Only a provider's answer is worth keeping: inconclusive checks say nothing about the
+	// secret, and local ones (structure, settings, reachability) are cheap and change with
+	// the configuration
+	if result.StatusCode != 0 && !result.Inconclusive {
+		v.mu.Lock()
+		v.cache[key] = result.clone()
+		v.mu.Unlock()
+	}
+
+	return result
 }
 
-// VerifySecret attempts to verify if a secret is active
-func (v *SecretVerifier) VerifySecret(secret SecretMatch) *VerificationResult {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// verify checks a secret, retrying transient failures within verifyBudget, records how
+// many requests it took and sends the audit hook an entry. With dryRun, requests are
+// audited but not sent.
+func (v *SecretVerifier) verify(ctx context.Context, secret SecretMatch, dryRun bool) *VerificationResult {
+	ctx, stop := v.budget.bound(ctx)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, v.verifyBudget())
 	defer cancel()
 
+	trace := &requestTrace{fragments: secretFragments.FindAllString(secret.RawValue, -1), dryRun: dryRun}
+	attempts := 0
+	result := v.dispatch(withAttemptCounter(withRequestTrace(ctx, trace), &attempts), secret)
+	result.Attempts = attempts
+	if result.StatusCode == http.StatusTooManyRequests {
+		result.Status = StatusRateLimited // Retries ran out while the provider was still rate limiting
+	}
+	if attempts > 1 && (result.Status == StatusError || result.Status == StatusTimeout) {
+		result.Note = fmt.Sprintf("%s (gave up after %d attempts)", result.Note, attempts)
+	}
+	result.finish()
+	if result.RateLimited {
+		trace.mu.Lock()
+		result.RetryAt = trace.retryAt
+		trace.mu.Unlock()
+	}
+
+	if v.audit != nil {
+		v.audit.Record(auditEntry(secret, trace, result))
+	}
+	return result
+}
+
+// dispatch sends a secret to the verifier for its type
+func (v *SecretVerifier) dispatch(ctx context.Context, secret SecretMatch) *VerificationResult {
+	if custom := v.custom[secret.Type]; custom != nil {
+		return v.verifyCustom(ctx, custom, secret.RawValue)
+	}
+
 	switch secret.Type {
 	case "AWS Access Key":
-		return v.verifyAWS(ctx, secret.Value)
+		return v.verifyAWS(ctx, secret.RawValue)
+	case "AWS Credentials":
+		return v.verifyAWSCredentials(ctx, secret.RawValue)
 	case "GitHub Token", "GitHub OAuth":
-		return v.verifyGitHub(ctx, secret.Value)
+		return v.verifyGitHub(ctx, secret.RawValue)
+	case "GitLab Token":
+		return v.verifyGitLab(ctx, secret.RawValue)
 	case "Slack Token":
-		return v.verifySlack(ctx, secret.Value)
+		return v.verifySlack(ctx, secret.RawValue)
 	case "Google API Key":
-		return v.verifyGoogleAPI(ctx, secret.Value)
+		return v.verifyGoogleAPI(ctx, secret.RawValue)
 	case "Stripe Secret Key", "Stripe Restricted Key":
-		return v.verifyStripe(ctx, secret.Value)
+		return v.verifyStripe(ctx, secret.RawValue)
+	case "Stripe Publishable Key":
+		return verifyStripePublishable(secret.RawValue)
 	case "SendGrid API Key":
-		return v.verifySendGrid(ctx, secret.Value)
+		return v.verifySendGrid(ctx, secret.RawValue)
 	case "JWT Token":
-		return v.verifyJWT(ctx, secret.Value)
+		return v.verifyJWT(ctx, secret)
+	case "Azure Storage Connection String", "Azure Storage Account Key":
+		return v.verifyAzureStorage(ctx, secret.RawValue)
+	case "Database Connection":
+		return v.verifyConnectivity(ctx, secret.RawValue)
+	case "Azure SAS Connection String":
+		return v.verifyAzureSAS(ctx, secret.RawValue)
+	case "Slack Webhook":
+		return v.verifySlackWebhook(ctx, secret.RawValue)
+	case "Discord Bot Token":
+		return v.verifyDiscordBot(ctx, secret.RawValue)
+	case "Discord Webhook":
+		return v.verifyDiscordWebhook(ctx, secret.RawValue)
+	case "Telegram Bot Token":
+		return v.verifyTelegram(ctx, secret.RawValue)
+	case "Mailgun API Key":
+		return v.verifyMailgun(ctx, secret.RawValue)
+	case "Mailchimp API Key":
+		return v.verifyMailchimp(ctx, secret.RawValue)
+	case "Postmark Server Token":
+		return v.verifyPostmark(ctx, secret.RawValue)
+	case "Heroku API Key":
+		return v.verifyHeroku(ctx, secret.RawValue)
+	case "PyPI API Token":
+		return v.verifyPyPI(ctx, secret.RawValue)
+	case "Docker Hub Token":
+		return v.verifyDockerHub(ctx, secret.RawValue)
+	case "npm Token":
+		return v.verifyNPM(ctx, secret.RawValue)
+	case "DigitalOcean Token":
+		return v.verifyDigitalOcean(ctx, secret.RawValue)
+	case "Linode Token":
+		return v.verifyLinode(ctx, secret.RawValue)
+	case "FCM Server Key":
+		return v.verifyFCM(ctx, secret.RawValue)
+	case "Firebase Database URL":
+		return v.verifyFirebase(ctx, secret.RawValue)
+	case "Datadog API Key":
+		return v.verifyDatadog(ctx, secret.RawValue)
+	case "New Relic User API Key", "New Relic REST API Key":
+		return v.verifyNewRelic(ctx, secret.RawValue)
+	case "Cloudflare API Token":
+		return v.verifyCloudflare(ctx, secret.RawValue)
+	case "Cloudflare Global API Key":
+		return v.verifyCloudflareGlobalKey(ctx, secret.RawValue)
+	case "Square Access Token":
+		return v.verifySquare(ctx, secret.RawValue)
+	case "PayPal Credentials":
+		return v.verifyPayPal(ctx, secret.RawValue, secret.Host)
+	case "Shopify Admin API Token", "Shopify Custom App Token", "Shopify Private App Token":
+		return v.verifyShopify(ctx, secret.RawValue, secret.Host)
+	case "Twilio Credentials", "Twilio Account SID":
+		return v.verifyTwilio(ctx, secret.RawValue)
 	default:
 		return &VerificationResult{
-			IsValid:    false,
-			Message:    "Verification not supported for this secret type",
+			Status:     StatusUnsupported,
+			Note:       "Verification not supported for this secret type",
 			VerifiedAt: time.Now(),
 		}
 	}
@@ -76,25 +472,204 @@ func (v *SecretVerifier) verifyAWS(ctx context.Context, _ string) *VerificationR
 	req, err := http.NewRequestWithContext(ctx, "POST", "https://sts.amazonaws.com/",
 		strings.NewReader("Action=GetCallerIdentity&Version=2011-06-15"))
 	if err != nil {
-		return &VerificationResult{IsValid: false, Message: "Failed to create request"}
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
 	}
 
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	resp, err := v.httpClient.Do(req)
 	if err != nil {
-		return &VerificationResult{IsValid: false, Message: "Request failed"}
+		return requestFailed(err)
 	}
 	defer resp.Body.Close()
 
 	// Note: Without the secret key, we can't truly verify
 	// But we can detect if the format is recognized
 	return &VerificationResult{
-		IsValid:    false,
-		Message:    "AWS verification requires both access key and secret key",
+		Status:     StatusUnsupported,
+		Note:       "AWS verification requires both access key and secret key",
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+}
+
+// defaultPorts are the ports dialed when a connection string does not name one
+var defaultPorts = map[string]string{
+	"mongodb": "27017", "mysql": "3306", "postgres": "5432", "postgresql": "5432",
+	"mssql": "1433", "sqlserver": "1433", "redis": "6379", "rediss": "6379",
+	"amqp": "5672", "amqps": "5671", "kafka": "9092", "elasticsearch": "9200",
+}
+
+// verifyConnectivity checks whether the host of a connection string accepts TCP
+// connections from the scanner. Credentials are never sent, and private or loopback
+// addresses are not dialed since they only describe the author's own network.
+func (v *SecretVerifier) verifyConnectivity(ctx context.Context, uri string) *VerificationResult {
+	if !v.connectivityChecks {
+		return &VerificationResult{
+			Status:     StatusUnsupported,
+			Note:       "Connectivity check disabled (deep_scan.verify_connectivity)",
+			VerifiedAt: time.Now(),
+		}
+	}
+
+	scheme, _, _, hosts := parseConnectionString(strings.TrimSpace(uri))
+	host, _, _ := strings.Cut(hosts, ",") // First host of a replica set is enough
+	if err := recordRequest(ctx, &url.URL{Scheme: scheme, Host: host}); err != nil {
+		return requestFailed(err)
+	}
+
+	var address string
+	if scheme == "mongodb+srv" {
+		_, records, err := net.DefaultResolver.LookupSRV(ctx, "mongodb", "tcp", host)
+		if err != nil || len(records) == 0 {
+			// A resolver failure says nothing about the database
+			return &VerificationResult{Status: StatusError, Note: "SRV lookup failed", VerifiedAt: time.Now()}
+		}
+		address = net.JoinHostPort(strings.TrimSuffix(records[0].Target, "."), strconv.Itoa(int(records[0].Port)))
+	} else if _, _, err := net.SplitHostPort(host); err == nil {
+		address = host
+	} else {
+		address = net.JoinHostPort(host, defaultPorts[scheme])
+	}
+
+	// The address is checked as it is dialed, so a host re-resolving to an internal
+	// address between a check and the connection (DNS rebinding) is still refused
+	conn, err := v.httpClient.dial(ctx, address, v.addressAllowed)
+	var dnsErr *net.DNSError
+	switch {
+	case errors.Is(err, errPrivateAddress):
+		return &VerificationResult{
+			Status:     StatusUnsupported,
+			Note:       "Private address - not dialed",
+			Details:    map[string]string{"address": address},
+			VerifiedAt: time.Now(),
+		}
+	case errors.As(err, &dnsErr):
+		// A resolver failure says nothing about the database
+		return &VerificationResult{
+			Status:     StatusError,
+			Note:       fmt.Sprintf("Could not resolve %s: %s", dnsErr.Name, dnsErr.Err),
+			Details:    map[string]string{"address": address},
+			VerifiedAt: time.Now(),
+		}
+	case err != nil:
+		return &VerificationResult{
+			Status:     StatusInvalid,
+			Note:       fmt.Sprintf("Unreachable - %s does not accept connections", address),
+			Details:    map[string]string{"address": address, "reachable": "false"},
+			VerifiedAt: time.Now(),
+		}
+	}
+	conn.Close()
+
+	return &VerificationResult{
+		Status:     StatusReachable,
+		Note:       fmt.Sprintf("%s accepts connections from the internet (credentials not tested)", address),
+		Details:    map[string]string{"address": address, "reachable": "true"},
+		VerifiedAt: time.Now(),
+	}
+}
+
+// verifyAWSCredentials checks an "accessKeyID:secretAccessKey" pair with a SigV4-signed
+// STS GetCallerIdentity call, which needs no IAM permissions and changes nothing
+func (v *SecretVerifier) verifyAWSCredentials(ctx context.Context, credential string) *VerificationResult {
+	accessKey, secretKey, ok := strings.Cut(strings.TrimSpace(credential), ":")
+	if !ok {
+		return &VerificationResult{Status: StatusInvalid, Note: "Expected access key and secret key", VerifiedAt: time.Now()}
+	}
+
+	body := "Action=GetCallerIdentity&Version=2011-06-15"
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://sts.amazonaws.com/", strings.NewReader(body))
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	signAWSRequest(req, body, accessKey, secretKey, "us-east-1", "sts", time.Now().UTC())
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
 		StatusCode: resp.StatusCode,
 		VerifiedAt: time.Now(),
 	}
+
+	switch resp.StatusCode {
+	case 200:
+		var identity struct {
+			Account string `xml:"GetCallerIdentityResult>Account"`
+			Arn     string `xml:"GetCallerIdentityResult>Arn"`
+		}
+		_ = xml.NewDecoder(resp.Body).Decode(&identity)
+		result.Status, result.Note = StatusActive, fmt.Sprintf("AWS account %s (%s)", identity.Account, identity.Arn)
+		result.Details = map[string]string{"account_id": identity.Account, "arn": identity.Arn}
+	case 400, 403:
+		var awsErr struct {
+			Code string `xml:"Error>Code"`
+		}
+		_ = xml.NewDecoder(resp.Body).Decode(&awsErr)
+		switch {
+		case awsErr.Code == "Throttling":
+			result.Status = StatusRateLimited
+		case strings.HasPrefix(accessKey, "ASIA"):
+			result.Status, result.Note = StatusInvalid, "Temporary credentials need a session token ("+awsErr.Code+")"
+		default:
+			result.Status, result.Note = StatusInvalid, "Credentials not valid ("+awsErr.Code+")"
+		}
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// signAWSRequest adds an AWS Signature Version 4 Authorization header to req
+func signAWSRequest(req *http.Request, body, accessKey, secretKey, region, service string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	payloadHash := sha256.Sum256([]byte(body))
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		"/",
+		"",
+		"content-type:" + req.Header.Get("Content-Type"),
+		"host:" + req.URL.Host,
+		"x-amz-date:" + amzDate,
+		"",
+		"content-type;host;x-amz-date",
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", date, region, service)
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	hmacSHA256 := func(key []byte, data string) []byte {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(data))
+		return mac.Sum(nil)
+	}
+	signingKey := hmacSHA256([]byte("AWS4"+secretKey), date)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=content-type;host;x-amz-date, Signature=%s",
+		accessKey, scope, signature))
 }
 
 // verifyGitHub checks if a GitHub token is valid
@@ -104,7 +679,7 @@ func (v *SecretVerifier) verifyGitHub(ctx context.Context, token string) *Verifi
 
 	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.github.com/user", nil)
 	if err != nil {
-		return &VerificationResult{IsValid: false, Message: "Failed to create request", VerifiedAt: time.Now()}
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
 	}
 
 	req.Header.Set("Authorization", "Bearer "+token)
@@ -112,7 +687,7 @@ func (v *SecretVerifier) verifyGitHub(ctx context.Context, token string) *Verifi
 
 	resp, err := v.httpClient.Do(req)
 	if err != nil {
-		return &VerificationResult{IsValid: false, Message: "Request failed: " + err.Error(), VerifiedAt: time.Now()}
+		return requestFailed(err)
 	}
 	defer resp.Body.Close()
 
@@ -123,131 +698,326 @@ func (v *SecretVerifier) verifyGitHub(ctx context.Context, token string) *Verifi
 
 	switch resp.StatusCode {
 	case 200:
-		result.IsValid = true
-		result.Message = "✅ ACTIVE - Token is valid and working"
+		result.Details = githubDetails(token, resp)
+		result.Status, result.Note = StatusActive, "Token is valid and working"
+		if login := result.Details["login"]; login != "" {
+			result.Note = fmt.Sprintf("%s of %s (scopes: %s)", result.Details["token_type"], login, result.Details["scopes"])
+		}
 	case 401:
-		result.IsValid = false
-		result.Message = "❌ INVALID - Token is not valid or expired"
+		result.Status, result.Note = StatusInvalid, "Token is not valid or expired"
 	case 403:
 		// Check if it's rate limiting or insufficient permissions
 		body, _ := io.ReadAll(resp.Body)
 		if strings.Contains(string(body), "rate limit") {
-			result.RateLimited = true
-			result.Message = "⏸️  RATE LIMITED - Cannot verify at this time"
+			result.Status = StatusRateLimited
 		} else {
-			result.IsValid = true
-			result.Message = "⚠️  VALID but insufficient permissions"
+			result.Status, result.Note = StatusActive, "Valid but insufficient permissions"
 		}
 	default:
-		result.Message = fmt.Sprintf("⚠️  Unexpected status: %d", resp.StatusCode)
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
 	}
 
 	return result
 }
 
-// verifySlack checks if a Slack token is valid
-func (v *SecretVerifier) verifySlack(ctx context.Context, token string) *VerificationResult {
-	token = strings.TrimSpace(token)
+// githubTokenTypes names GitHub tokens by prefix
+var githubTokenTypes = []struct{ prefix, name string }{
+	{"github_pat_", "fine-grained PAT"},
+	{"ghp_", "classic PAT"},
+	{"gho_", "OAuth token"},
+	{"ghu_", "GitHub App user token"},
+	{"ghs_", "GitHub App installation token"},
+	{"ghr_", "refresh token"},
+}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://slack.com/api/auth.test", nil)
-	if err != nil {
-		return &VerificationResult{IsValid: false, Message: "Failed to create request", VerifiedAt: time.Now()}
+// githubDetails reads the token type, the account's login and the token's scopes from a
+// successful /user response. Only classic tokens send X-OAuth-Scopes; fine-grained tokens
+// have per-repository permissions instead, noted as "fine-grained".
+func githubDetails(token string, resp *http.Response) map[string]string {
+	details := map[string]string{"token_type": "token"}
+	for _, tokenType := range githubTokenTypes {
+		if strings.HasPrefix(token, tokenType.prefix) {
+			details["token_type"] = tokenType.name
+			break
+		}
 	}
 
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	var user struct {
+		Login string `json:"login"`
+		Type  string `json:"type"` // User, Bot or Organization
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&user); err == nil {
+		details["login"] = user.Login
+		if user.Type != "" && user.Type != "User" {
+			details["account_type"] = user.Type
+		}
+	}
 
-	resp, err := v.httpClient.Do(req)
-	if err != nil {
-		return &VerificationResult{IsValid: false, Message: "Request failed", VerifiedAt: time.Now()}
+	if scopes, ok := resp.Header["X-Oauth-Scopes"]; ok {
+		details["scopes"] = strings.Join(strings.Fields(strings.ReplaceAll(strings.Join(scopes, ","), ",", " ")), ", ")
+		if details["scopes"] == "" {
+			details["scopes"] = "none"
+		}
+	} else {
+		details["scopes"] = "fine-grained"
 	}
-	defer resp.Body.Close()
+	return details
+}
+
+// DangerousGitHubScope reports whether a GitHub OAuth scope gives write access to code,
+// CI or an organization: repo, workflow, delete_repo and the admin:* scopes
+func DangerousGitHubScope(scope string) bool {
+	switch scope {
+	case "repo", "workflow", "delete_repo":
+		return true
+	}
+	return strings.HasPrefix(scope, "admin:")
+}
+
+// DangerousScope reports whether a verified token's scope is high risk, for GitHub (see
+// DangerousGitHubScope) or SendGrid (see DangerousSendGridScope), whose names don't overlap
+func DangerousScope(scope string) bool {
+	return DangerousGitHubScope(scope) || DangerousSendGridScope(scope)
+}
 
-	var slackResp struct {
-		Ok    bool   `json:"ok"`
-		Error string `json:"error"`
+// ScopeBadge is one scope of a verified token, as reports and emails show it
+type ScopeBadge struct {
+	Scope     string
+	Dangerous bool // See DangerousScope
+}
+
+// ScopeBadges lists the scopes in a verification's details for display. The values
+// "none" and "fine-grained", which say a GitHub token has no classic scopes rather than
+// name one, are left out.
+func ScopeBadges(details map[string]string) []ScopeBadge {
+	var badges []ScopeBadge
+	for _, scope := range strings.Split(details["scopes"], ", ") {
+		switch scope {
+		case "", "none", "fine-grained":
+			continue
+		}
+		badges = append(badges, ScopeBadge{Scope: scope, Dangerous: DangerousScope(scope)})
 	}
+	return badges
+}
+
+// slackAuthErrors are the status and explanation of the auth.test errors that reject a
+// token, which call for different remediation: a revoked token is dead, a deactivated
+// user's tokens come back with the user, and an unknown token may belong to another Slack
+// (e.g. GovSlack)
+var slackAuthErrors = map[string]struct {
+	status VerificationStatus
+	reason string
+}{
+	"token_revoked":    {StatusRevoked, "Token revoked"},
+	"token_expired":    {StatusExpired, "Token expired"},
+	"account_inactive": {StatusInactive, "Token of a deactivated user or uninstalled app - it works again if they are reactivated"},
+	"invalid_auth":     {StatusInvalid, "Token not recognized"},
+	"not_authed":       {StatusInvalid, "No token accepted"},
+}
+
+// verifySlack checks if a Slack token is valid, and reports the workspace and the user or
+// bot it acts as. Bot tokens also list the workspaces of an org-wide install.
+func (v *SecretVerifier) verifySlack(ctx context.Context, token string) *VerificationResult {
+	token = strings.TrimSpace(token)
 
-	if err := json.NewDecoder(resp.Body).Decode(&slackResp); err != nil {
-		return &VerificationResult{IsValid: false, Message: "Invalid response", VerifiedAt: time.Now()}
+	var identity struct {
+		Ok           bool   `json:"ok"`
+		Error        string `json:"error"`
+		URL          string `json:"url"`
+		Team         string `json:"team"`
+		TeamID       string `json:"team_id"`
+		User         string `json:"user"`
+		UserID       string `json:"user_id"`
+		BotID        string `json:"bot_id"`
+		EnterpriseID string `json:"enterprise_id"`
+	}
+	status, header, err := v.slackCall(ctx, token, "auth.test", &identity)
+	if status == 0 {
+		return requestFailed(err)
 	}
 
 	result := &VerificationResult{
-		StatusCode: resp.StatusCode,
+		StatusCode: status,
 		VerifiedAt: time.Now(),
-		IsValid:    slackResp.Ok,
 	}
 
-	if slackResp.Ok {
-		result.Message = "✅ ACTIVE - Token is valid"
-	} else {
-		result.Message = fmt.Sprintf("❌ INVALID - %s", slackResp.Error)
+	switch {
+	case status == http.StatusTooManyRequests:
+		result.Status = StatusRateLimited
+		return result
+	case err != nil:
+		result.Status, result.Note = StatusError, "Invalid response"
+		return result
+	}
+
+	if !identity.Ok {
+		switch authError, known := slackAuthErrors[identity.Error]; {
+		case identity.Error == "ratelimited":
+			result.Status = StatusRateLimited
+		case known:
+			result.Status, result.Note = authError.status, fmt.Sprintf("%s (%s)", authError.reason, identity.Error)
+		default:
+			result.Status, result.Note = StatusInvalid, identity.Error
+		}
+		if identity.Error != "" {
+			result.Details = map[string]string{"error": identity.Error}
+		}
+		return result
+	}
+
+	result.Details = make(map[string]string)
+	for key, value := range map[string]string{
+		"team":          identity.Team,
+		"team_id":       identity.TeamID,
+		"user":          identity.User,
+		"user_id":       identity.UserID,
+		"bot_id":        identity.BotID,
+		"enterprise_id": identity.EnterpriseID,
+		"url":           identity.URL,
+		"scopes":        header.Get("X-OAuth-Scopes"),
+	} {
+		if value != "" {
+			result.Details[key] = value
+		}
+	}
+
+	if strings.HasPrefix(token, "xoxb-") {
+		if teams := v.slackTeams(ctx, token); teams != "" {
+			result.Details["teams"] = teams
+		}
+	}
+
+	result.Status = StatusActive
+	switch {
+	case identity.BotID != "":
+		result.Note = fmt.Sprintf("Bot %s in workspace %s", identity.User, identity.Team)
+	case identity.User != "":
+		result.Note = fmt.Sprintf("User %s in workspace %s", identity.User, identity.Team)
+	default:
+		result.Note = "Token is valid"
+	}
+	if teams := result.Details["teams"]; teams != "" {
+		result.Note += fmt.Sprintf(" (installed in: %s)", teams)
 	}
 
 	return result
 }
 
-// verifyGoogleAPI checks if a Google API key is valid
-func (v *SecretVerifier) verifyGoogleAPI(ctx context.Context, apiKey string) *VerificationResult {
-	apiKey = strings.TrimSpace(apiKey)
+// slackTeams lists the workspaces a bot token's org-wide install covers, or "" when the
+// token may not list them (missing_scope) or the call fails - it only adds detail
+func (v *SecretVerifier) slackTeams(ctx context.Context, token string) string {
+	var teams struct {
+		Ok    bool `json:"ok"`
+		Teams []struct {
+			Name string `json:"name"`
+		} `json:"teams"`
+	}
+	if _, _, err := v.slackCall(ctx, token, "auth.teams.list", &teams); err != nil || !teams.Ok {
+		return ""
+	}
+
+	names := make([]string, 0, len(teams.Teams))
+	for _, team := range teams.Teams {
+		names = append(names, team.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// slackCall sends an authenticated call of a Slack Web API method and decodes its answer
+// into target, returning the status and headers; a status of 0 means no answer came
+func (v *SecretVerifier) slackCall(ctx context.Context, token, method string, target interface{}) (int, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://slack.com/api/"+method, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode, resp.Header, json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(target)
+}
 
-	// Use the simplest Google API endpoint - just check if key is recognized
-	url := fmt.Sprintf("https://www.googleapis.com/youtube/v3/search?part=snippet&q=test&key=%s", apiKey)
+// verifySlackWebhook checks if a Slack incoming webhook is live without posting a message.
+// An empty body is rejected before delivery: live hooks answer "invalid_payload", revoked
+// ones "no_service" (404) or similar errors.
+func (v *SecretVerifier) verifySlackWebhook(ctx context.Context, webhookURL string) *VerificationResult {
+	webhookURL = strings.TrimSpace(webhookURL)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	// Never send a payload - an empty body cannot produce a chat message
+	req, err := http.NewRequestWithContext(ctx, "POST", webhookURL, strings.NewReader(""))
 	if err != nil {
-		return &VerificationResult{IsValid: false, Message: "Failed to create request", VerifiedAt: time.Now()}
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
 	}
 
+	req.Header.Set("Content-Type", "application/json")
+
 	resp, err := v.httpClient.Do(req)
 	if err != nil {
-		return &VerificationResult{IsValid: false, Message: "Request failed", VerifiedAt: time.Now()}
+		return requestFailed(err)
 	}
 	defer resp.Body.Close()
 
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	reason := strings.TrimSpace(string(body))
+
 	result := &VerificationResult{
 		StatusCode: resp.StatusCode,
 		VerifiedAt: time.Now(),
 	}
 
-	switch resp.StatusCode {
-	case 200:
-		result.IsValid = true
-		result.Message = "✅ ACTIVE - API key is valid"
-	case 400:
-		body, _ := io.ReadAll(resp.Body)
-		if strings.Contains(string(body), "API key not valid") {
-			result.Message = "❌ INVALID - API key not recognized"
-		} else if strings.Contains(string(body), "disabled") {
-			result.IsValid = true
-			result.Message = "⚠️  VALID but API disabled for this key"
-		} else {
-			result.Message = "⚠️  Cannot verify - ambiguous response"
+	switch {
+	case strings.Contains(reason, "invalid_payload") || strings.Contains(reason, "no_text"):
+		result.Status, result.Note = StatusActive, "Webhook accepts requests"
+		if result.Details = slackWebhookDetails(webhookURL); result.Details != nil {
+			result.Note = fmt.Sprintf("Webhook of workspace %s accepts requests", result.Details["team_id"])
 		}
-	case 403:
-		result.IsValid = true
-		result.Message = "⚠️  VALID but quota exceeded or API not enabled"
+	case strings.Contains(reason, "no_service") || strings.Contains(reason, "no_team") ||
+		strings.Contains(reason, "invalid_token") || strings.Contains(reason, "channel_is_archived") ||
+		resp.StatusCode == 404:
+		result.Status, result.Note = StatusInvalid, fmt.Sprintf("Webhook revoked (%s)", reason)
+	case resp.StatusCode == 429:
+		result.Status = StatusRateLimited
 	default:
-		result.Message = fmt.Sprintf("⚠️  Unexpected status: %d", resp.StatusCode)
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d (%s)", resp.StatusCode, reason)
 	}
 
 	return result
 }
 
-// verifyStripe checks if a Stripe key is valid
-func (v *SecretVerifier) verifyStripe(ctx context.Context, key string) *VerificationResult {
-	key = strings.TrimSpace(key)
+// slackWebhookDetails reads the workspace and the app's bot IDs from a webhook URL,
+// hooks.slack.com/services/<team>/<bot>/<secret>; posting to a hook reveals nothing more
+func slackWebhookDetails(webhookURL string) map[string]string {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil {
+		return nil
+	}
+	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(parts) != 4 || parts[0] != "services" || !strings.HasPrefix(parts[1], "T") || !strings.HasPrefix(parts[2], "B") {
+		return nil
+	}
+	return map[string]string{"team_id": parts[1], "bot_id": parts[2]}
+}
+
+// verifyDiscordBot checks if a Discord bot token is valid
+func (v *SecretVerifier) verifyDiscordBot(ctx context.Context, token string) *VerificationResult {
+	token = strings.TrimSpace(token)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.stripe.com/v1/customers?limit=1", nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://discord.com/api/v10/users/@me", nil)
 	if err != nil {
-		return &VerificationResult{IsValid: false, Message: "Failed to create request", VerifiedAt: time.Now()}
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
 	}
 
-	req.SetBasicAuth(key, "")
+	req.Header.Set("Authorization", "Bot "+token)
 
 	resp, err := v.httpClient.Do(req)
 	if err != nil {
-		return &VerificationResult{IsValid: false, Message: "Request failed", VerifiedAt: time.Now()}
+		return requestFailed(err)
 	}
 	defer resp.Body.Close()
 
@@ -258,32 +1028,36 @@ func (v *SecretVerifier) verifyStripe(ctx context.Context, key string) *Verifica
 
 	switch resp.StatusCode {
 	case 200:
-		result.IsValid = true
-		result.Message = "✅ ACTIVE - Stripe key is valid"
+		var user struct {
+			ID       string `json:"id"`
+			Username string `json:"username"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&user)
+		result.Status, result.Note = StatusActive, fmt.Sprintf("Bot token for '%s' (ID: %s)", user.Username, user.ID)
+		result.Details = map[string]string{"login": user.Username, "user_id": user.ID}
 	case 401:
-		result.IsValid = false
-		result.Message = "❌ INVALID - Key is not valid"
+		result.Status, result.Note = StatusInvalid, "Bot token not valid"
+	case 429:
+		result.Status = StatusRateLimited
 	default:
-		result.Message = fmt.Sprintf("⚠️  Unexpected status: %d", resp.StatusCode)
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
 	}
 
 	return result
 }
 
-// verifySendGrid checks if a SendGrid API key is valid
-func (v *SecretVerifier) verifySendGrid(ctx context.Context, apiKey string) *VerificationResult {
-	apiKey = strings.TrimSpace(apiKey)
+// verifyDiscordWebhook checks if a Discord webhook exists (GET returns metadata, never posts)
+func (v *SecretVerifier) verifyDiscordWebhook(ctx context.Context, webhookURL string) *VerificationResult {
+	webhookURL = strings.TrimSpace(webhookURL)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.sendgrid.com/v3/scopes", nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", webhookURL, nil)
 	if err != nil {
-		return &VerificationResult{IsValid: false, Message: "Failed to create request", VerifiedAt: time.Now()}
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
 	}
 
-	req.Header.Set("Authorization", "Bearer "+apiKey)
-
 	resp, err := v.httpClient.Do(req)
 	if err != nil {
-		return &VerificationResult{IsValid: false, Message: "Request failed", VerifiedAt: time.Now()}
+		return requestFailed(err)
 	}
 	defer resp.Body.Close()
 
@@ -294,79 +1068,1733 @@ func (v *SecretVerifier) verifySendGrid(ctx context.Context, apiKey string) *Ver
 
 	switch resp.StatusCode {
 	case 200:
-		result.IsValid = true
-		result.Message = "✅ ACTIVE - SendGrid API key is valid"
-	case 401, 403:
-		result.IsValid = false
-		result.Message = "❌ INVALID - API key not valid"
+		var webhook struct {
+			Name      string `json:"name"`
+			ChannelID string `json:"channel_id"`
+			GuildID   string `json:"guild_id"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&webhook)
+		result.Status, result.Note = StatusActive, fmt.Sprintf("Webhook '%s' (guild: %s, channel: %s)", webhook.Name, webhook.GuildID, webhook.ChannelID)
+		result.Details = map[string]string{"webhook": webhook.Name, "guild_id": webhook.GuildID, "channel_id": webhook.ChannelID}
+	case 401, 404:
+		result.Status, result.Note = StatusInvalid, "Webhook not found or token invalid"
+	case 429:
+		result.Status = StatusRateLimited
 	default:
-		result.Message = fmt.Sprintf("⚠️  Unexpected status: %d", resp.StatusCode)
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
 	}
 
 	return result
 }
 
-// verifyJWT analyzes JWT structure (doesn't validate signature)
-func (v *SecretVerifier) verifyJWT(_ context.Context, token string) *VerificationResult {
-	parts := strings.Split(token, ".")
-	if len(parts) != 3 {
-		return &VerificationResult{
-			IsValid:    false,
-			Message:    "❌ INVALID - Malformed JWT",
-			VerifiedAt: time.Now(),
-		}
+// verifyTelegram checks if a Telegram bot token is valid via getMe
+func (v *SecretVerifier) verifyTelegram(ctx context.Context, token string) *VerificationResult {
+	token = strings.TrimSpace(token)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.telegram.org/bot"+token+"/getMe", nil)
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	var telegramResp struct {
+		Ok          bool   `json:"ok"`
+		Description string `json:"description"`
+		Result      struct {
+			Username string `json:"username"`
+		} `json:"result"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&telegramResp); err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Invalid response", StatusCode: resp.StatusCode, VerifiedAt: time.Now()}
+	}
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	switch {
+	case telegramResp.Ok:
+		result.Status, result.Note = StatusActive, fmt.Sprintf("Bot @%s", telegramResp.Result.Username)
+		result.Details = map[string]string{"login": telegramResp.Result.Username}
+	case resp.StatusCode == 429:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusInvalid, telegramResp.Description
+	}
+
+	return result
+}
+
+// verifyGoogleAPI checks a Google API key against a few cheap read-only endpoints of
+// different services, stopping at the first that accepts it. A key can be valid yet not
+// enabled for a given API, so "API not enabled" answers count as proof the key exists,
+// and a key locked to HTTP referrers, IPs or apps is reported with its restriction.
+func (v *SecretVerifier) verifyGoogleAPI(ctx context.Context, apiKey string) *VerificationResult {
+	apiKey = strings.TrimSpace(apiKey)
+
+	result := &VerificationResult{VerifiedAt: time.Now()}
+	var notEnabled []string
+	var outOfQuota string // A service that refused the key for quota
+	var lastErr error
+
+	for i, probe := range googleProbes {
+		if i == googleProbeLimit {
+			break
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", probe.url+url.QueryEscape(apiKey), nil)
+		if err != nil {
+			return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+		}
+		resp, err := v.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		resp.Body.Close()
+		result.StatusCode = resp.StatusCode
+
+		outcome, detail := googleProbeOutcome(resp.StatusCode, body)
+		switch outcome {
+		case googleAccepted:
+			result.Status, result.Note = StatusActive, fmt.Sprintf("API key accepted by %s", probe.name)
+			result.Details = map[string]string{"services": probe.name}
+			if len(notEnabled) > 0 {
+				result.Details["not_enabled"] = strings.Join(notEnabled, ",")
+			}
+			return result
+		case googleInvalid:
+			result.Status, result.Note = StatusInvalid, "API key not recognized"
+			return result
+		case googleRestricted:
+			// Restrictions apply to the key as a whole; other services answer the same
+			result.Status, result.Note = StatusActive, fmt.Sprintf("Valid key, restricted (%s) - refused from this host", detail)
+			result.Details = map[string]string{"restriction": detail}
+			return result
+		case googleNotEnabled:
+			notEnabled = append(notEnabled, probe.name)
+		case googleQuota:
+			outOfQuota = probe.name
+		}
+	}
+
+	switch {
+	case len(notEnabled) > 0:
+		result.Status, result.Note = StatusActive, fmt.Sprintf("Valid key, but none of the probed APIs is enabled (%s)", strings.Join(notEnabled, ", "))
+		result.Details = map[string]string{"not_enabled": strings.Join(notEnabled, ",")}
+	case outOfQuota != "":
+		result.Status, result.Note = StatusActive, fmt.Sprintf("Valid key, %s quota exceeded", outOfQuota)
+	case lastErr != nil && result.StatusCode == 0:
+		return requestFailed(lastErr)
+	default:
+		result.Status, result.Note = StatusError, "Ambiguous response"
+	}
+	return result
+}
+
+// googleProbeLimit caps the requests made per Google API key
+const googleProbeLimit = 4
+
+// googleProbes are the endpoints verifyGoogleAPI tries, cheapest first; each URL ends in
+// "key=". None of them spends more than a single quota unit or changes anything.
+var googleProbes = []struct{ name, url string }{
+	{"youtube", "https://www.googleapis.com/youtube/v3/i18nLanguages?part=snippet&key="},
+	{"maps_static", "https://maps.googleapis.com/maps/api/streetview/metadata?location=0,0&key="},
+	{"geocoding", "https://maps.googleapis.com/maps/api/geocode/json?address=London&key="},
+	{"translate", "https://translation.googleapis.com/language/translate/v2/languages?key="},
+}
+
+// Outcomes of probing one Google API with a key
+const (
+	googleUnknown    = iota
+	googleAccepted   // The API answered
+	googleInvalid    // The key doesn't exist
+	googleRestricted // The key exists but is locked to other referrers, IPs or apps
+	googleNotEnabled // The key exists but its project hasn't enabled (or billed) this API
+	googleQuota      // The key exists but is out of quota
+)
+
+// googleRestrictions maps error reasons of restricted keys to the restriction reported
+var googleRestrictions = map[string]string{
+	"API_KEY_HTTP_REFERRER_BLOCKED": "http_referrer",
+	"API_KEY_IP_ADDRESS_BLOCKED":    "ip_address",
+	"API_KEY_ANDROID_APP_BLOCKED":   "android_app",
+	"API_KEY_IOS_APP_BLOCKED":       "ios_app",
+	"ipRefererBlocked":              "ip_or_referrer",
+}
+
+// googleProbeOutcome classifies a Google API response. Most APIs send a JSON error with
+// reasons; the Maps web services answer 200 with a status and an error_message.
+func googleProbeOutcome(statusCode int, body []byte) (int, string) {
+	var response struct {
+		Status       string `json:"status"`
+		ErrorMessage string `json:"error_message"`
+		Error        struct {
+			Message string `json:"message"`
+			Errors  []struct {
+				Reason string `json:"reason"`
+			} `json:"errors"`
+			Details []struct {
+				Reason string `json:"reason"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	_ = json.Unmarshal(body, &response)
+
+	reasons := make(map[string]bool)
+	for _, e := range response.Error.Errors {
+		reasons[e.Reason] = true
+	}
+	for _, d := range response.Error.Details {
+		reasons[d.Reason] = true
+	}
+	message := strings.ToLower(response.Error.Message + " " + response.ErrorMessage)
+
+	switch {
+	case statusCode == 200 && (response.Status == "" || response.Status == "OK" || response.Status == "ZERO_RESULTS" || response.Status == "NOT_FOUND"):
+		return googleAccepted, ""
+	case reasons["API_KEY_INVALID"] || reasons["keyInvalid"] ||
+		strings.Contains(message, "api key not valid") || strings.Contains(message, "provided api key is invalid"):
+		return googleInvalid, ""
+	}
+
+	for reason, restriction := range googleRestrictions {
+		if reasons[reason] {
+			return googleRestricted, restriction
+		}
+	}
+	switch {
+	case strings.Contains(message, "referer restrictions"):
+		return googleRestricted, "http_referrer"
+	case strings.Contains(message, "not authorized to use this api key"):
+		// Maps: "This IP, site or mobile application is not authorized to use this API key"
+		return googleRestricted, "ip_or_referrer"
+	case reasons["SERVICE_DISABLED"] || reasons["accessNotConfigured"] || reasons["API_KEY_SERVICE_BLOCKED"] ||
+		strings.Contains(message, "has not been used in project") || strings.Contains(message, "is disabled") ||
+		strings.Contains(message, "not authorized to use this api") || strings.Contains(message, "enable billing"):
+		return googleNotEnabled, ""
+	case statusCode == http.StatusTooManyRequests || response.Status == "OVER_QUERY_LIMIT" || response.Status == "OVER_DAILY_LIMIT" ||
+		reasons["RATE_LIMIT_EXCEEDED"] || reasons["quotaExceeded"] || reasons["dailyLimitExceeded"] || reasons["rateLimitExceeded"]:
+		return googleQuota, ""
+	}
+	return googleUnknown, ""
+}
+
+// verifyStripe checks a Stripe secret or restricted key against the account it belongs to,
+// reporting the key mode and, for restricted keys, which resources it can read
+func (v *SecretVerifier) verifyStripe(ctx context.Context, key string) *VerificationResult {
+	key = strings.TrimSpace(key)
+
+	mode := "live"
+	if strings.Contains(key, "_test_") {
+		mode = "test"
+	}
+	restricted := strings.HasPrefix(key, "rk_")
+
+	status, body, err := v.stripeGet(ctx, key, "/v1/account")
+	if err != nil {
+		return requestFailed(err)
+	}
+
+	result := &VerificationResult{
+		StatusCode: status,
+		VerifiedAt: time.Now(),
+		Details:    map[string]string{"key_mode": mode},
+	}
+
+	switch status {
+	case 200:
+		var account struct {
+			ID              string `json:"id"`
+			Email           string `json:"email"`
+			BusinessProfile struct {
+				Name string `json:"name"`
+			} `json:"business_profile"`
+			Settings struct {
+				Dashboard struct {
+					DisplayName string `json:"display_name"`
+				} `json:"dashboard"`
+			} `json:"settings"`
+		}
+		_ = json.Unmarshal(body, &account)
+		name := account.BusinessProfile.Name
+		if name == "" {
+			name = account.Settings.Dashboard.DisplayName
+		}
+		result.Details["account_id"] = account.ID
+		if name != "" {
+			result.Details["business_name"] = name
+		}
+		if account.Email != "" {
+			result.Details["email"] = account.Email
+		}
+	case 403:
+		// Restricted keys without account read access are still live
+		if !restricted {
+			result.Status, result.Note = StatusError, "Key authenticated but was refused the account"
+			return result
+		}
+	case 401:
+		result.Status, result.Note = StatusInvalid, "Key is not valid"
+		return result
+	case 429:
+		result.Status = StatusRateLimited
+		return result
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", status)
+		return result
+	}
+
+	account := result.Details["account_id"]
+	if name := result.Details["business_name"]; name != "" {
+		account = fmt.Sprintf("%s (%s)", account, name)
+	}
+	if account == "" {
+		account = "an unknown account"
+	}
+
+	if !restricted {
+		result.Details["access"] = "full"
+		result.Status, result.Note = StatusActive, fmt.Sprintf("Stripe %s secret key for %s, full API access", mode, account)
+		return result
+	}
+
+	// A restricted key can do only what it was granted; find out what it can read
+	var readable []string
+	for _, probe := range stripeReadProbes {
+		status, _, err := v.stripeGet(ctx, key, probe.path)
+		if err != nil {
+			break
+		}
+		if status == 200 {
+			readable = append(readable, probe.name)
+		}
+	}
+	result.Details["access"] = "restricted"
+	if len(readable) > 0 {
+		result.Details["can_read"] = strings.Join(readable, ",")
+	}
+
+	summary := "no probed read access"
+	if len(readable) > 0 {
+		summary = "can read " + strings.Join(readable, ", ")
+	}
+	result.Status, result.Note = StatusActive, fmt.Sprintf("Stripe %s restricted key for %s, %s", mode, account, summary)
+	return result
+}
+
+// stripeReadProbes are harmless list/read endpoints used to characterize what a restricted
+// Stripe key may access. Only GET requests are ever sent with a found Stripe key.
+var stripeReadProbes = []struct{ name, path string }{
+	{"balance", "/v1/balance"},
+	{"charges", "/v1/charges?limit=1"},
+	{"customers", "/v1/customers?limit=1"},
+	{"payment_intents", "/v1/payment_intents?limit=1"},
+	{"payouts", "/v1/payouts?limit=1"},
+}
+
+// stripeGet sends a GET request to the Stripe API with key and returns the status and body
+func (v *SecretVerifier) stripeGet(ctx context.Context, key, path string) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.stripe.com"+path, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	req.SetBasicAuth(key, "")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	return resp.StatusCode, body, err
+}
+
+// verifyStripePublishable reports a publishable key without contacting Stripe: it is
+// meant to ship in web pages, and every endpoint that accepts it creates objects
+func verifyStripePublishable(key string) *VerificationResult {
+	mode := "live"
+	if strings.Contains(key, "_test_") {
+		mode = "test"
+	}
+	return &VerificationResult{
+		Status:     StatusUnsupported,
+		Note:       fmt.Sprintf("Stripe %s publishable key - public by design, look for its secret key", mode),
+		VerifiedAt: time.Now(),
+		Details:    map[string]string{"key_mode": mode, "access": "publishable"},
+	}
+}
+
+// verifySendGrid checks a SendGrid API key by listing its scopes, flagging the ones that
+// create, update or delete keys, teammates or sender domains, and looks up the account it belongs to
+func (v *SecretVerifier) verifySendGrid(ctx context.Context, apiKey string) *VerificationResult {
+	apiKey = strings.TrimSpace(apiKey)
+
+	status, body, err := v.sendGridGet(ctx, apiKey, "/v3/scopes")
+	if err != nil {
+		return requestFailed(err)
+	}
+
+	result := &VerificationResult{
+		StatusCode: status,
+		VerifiedAt: time.Now(),
+	}
+
+	switch status {
+	case 200:
+	case 401, 403:
+		result.Status, result.Note = StatusInvalid, "API key not valid"
+		return result
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", status)
+		return result
+	}
+
+	var granted struct {
+		Scopes []string `json:"scopes"`
+	}
+	_ = json.Unmarshal(body, &granted)
+	groups := sendGridScopeGroups(granted.Scopes)
+	result.Details = map[string]string{"scope_count": strconv.Itoa(len(granted.Scopes))}
+	if len(groups) > 0 {
+		result.Details["scopes"] = strings.Join(groups, ", ")
+	}
+
+	var highRisk []string
+	for _, scope := range granted.Scopes {
+		if DangerousSendGridScope(scope) {
+			highRisk = append(highRisk, scope)
+		}
+	}
+	sort.Strings(highRisk)
+	if len(highRisk) > 0 {
+		result.Details["high_risk_scopes"] = strings.Join(highRisk, ", ")
+	}
+
+	// Needs the user.account.read scope; keys without it just don't say
+	kind := "SendGrid key"
+	if status, body, err := v.sendGridGet(ctx, apiKey, "/v3/user/account"); err == nil && status == 200 {
+		var account struct {
+			Type string `json:"type"` // free, paid or subuser
+		}
+		if json.Unmarshal(body, &account) == nil && account.Type != "" {
+			result.Details["account_type"] = account.Type
+			if account.Type == "subuser" {
+				result.Details["subuser"] = "true"
+				kind = "SendGrid subuser key"
+			}
+		}
+	}
+
+	switch {
+	case len(granted.Scopes) == 0:
+		result.Note = kind + " is valid (scopes unknown)"
+	case len(groups) <= 5:
+		result.Note = fmt.Sprintf("%s with %d scope(s): %s", kind, len(granted.Scopes), result.Details["scopes"])
+	default:
+		result.Note = fmt.Sprintf("%s with %d scopes", kind, len(granted.Scopes))
+	}
+	if len(highRisk) > 0 {
+		result.Note += " - HIGH RISK: " + strings.Join(highRisk, ", ")
+	}
+	result.Status = StatusActive
+	return result
+}
+
+// sendGridGet sends an authenticated GET to the SendGrid API, returning the status and
+// up to 1 MB of the body
+func (v *SecretVerifier) sendGridGet(ctx context.Context, apiKey, path string) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.sendgrid.com"+path, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	return resp.StatusCode, body, err
+}
+
+// sendGridScopeGroups condenses a key's scopes, of which a full access key has hundreds,
+// to one entry per resource: the scope itself when it is the only one for its resource
+// (e.g. "mail.send"), otherwise "<resource> (<n> scopes)". Scopes that can manage keys,
+// teammates or sender domains are always listed by name so they stand out.
+func sendGridScopeGroups(scopes []string) []string {
+	byResource := make(map[string][]string)
+	var groups []string
+	for _, scope := range scopes {
+		if DangerousSendGridScope(scope) {
+			groups = append(groups, scope)
+			continue
+		}
+		resource, _, _ := strings.Cut(scope, ".")
+		byResource[resource] = append(byResource[resource], scope)
+	}
+
+	for resource, resourceScopes := range byResource {
+		if len(resourceScopes) == 1 {
+			groups = append(groups, resourceScopes[0])
+		} else {
+			groups = append(groups, fmt.Sprintf("%s (%d scopes)", resource, len(resourceScopes)))
+		}
+	}
+	sort.Strings(groups)
+	return groups
+}
+
+// DangerousSendGridScope reports whether a SendGrid scope creates, updates or deletes API
+// keys, teammates or sender domains and links (e.g. "api_keys.create"); reading them is
+// not flagged
+func DangerousSendGridScope(scope string) bool {
+	resource, _, _ := strings.Cut(scope, ".")
+	switch resource {
+	case "api_keys", "teammates", "whitelabel":
+	default:
+		return false
+	}
+	switch scope[strings.LastIndex(scope, ".")+1:] {
+	case "create", "update", "delete":
+		return true
+	}
+	return false
+}
+
+// verifyMailgun checks if a Mailgun API key is valid by listing domains
+func (v *SecretVerifier) verifyMailgun(ctx context.Context, apiKey string) *VerificationResult {
+	apiKey = strings.TrimSpace(apiKey)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.mailgun.net/v3/domains", nil)
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	req.SetBasicAuth("api", apiKey)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	switch resp.StatusCode {
+	case 200:
+		var domains struct {
+			TotalCount int `json:"total_count"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&domains)
+		result.Status, result.Note = StatusActive, fmt.Sprintf("Mailgun key with access to %d domain(s)", domains.TotalCount)
+		result.Details = map[string]string{"domains": strconv.Itoa(domains.TotalCount)}
+	case 401, 403:
+		result.Status, result.Note = StatusInvalid, "API key not valid"
+	case 429:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// verifyPostmark checks if a Postmark server token is valid by fetching its server
+func (v *SecretVerifier) verifyPostmark(ctx context.Context, token string) *VerificationResult {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.postmarkapp.com/server", nil)
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	req.Header.Set("X-Postmark-Server-Token", token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	switch resp.StatusCode {
+	case 200:
+		var server struct {
+			Name string `json:"Name"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&server)
+		result.Status, result.Note = StatusActive, fmt.Sprintf("Postmark server: %s", server.Name)
+		result.Details = map[string]string{"server": server.Name}
+	case 401, 403:
+		result.Status, result.Note = StatusInvalid, "Server token not valid"
+	case 429:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// verifyHeroku checks a Heroku API key by reading the account it belongs to from the
+// Platform API. A 403 means the key authenticated but may not read the account (e.g. an
+// OAuth token without the identity scope), so it is still live - unless the account is
+// suspended.
+func (v *SecretVerifier) verifyHeroku(ctx context.Context, apiKey string) *VerificationResult {
+	apiKey = strings.TrimSpace(apiKey)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.heroku.com/account", nil)
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.Header.Set("Accept", "application/vnd.heroku+json; version=3")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	switch resp.StatusCode {
+	case 200:
+		var account struct {
+			ID    string `json:"id"`
+			Email string `json:"email"`
+		}
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&account)
+		result.Status, result.Note = StatusActive, fmt.Sprintf("Heroku account %s", account.Email)
+		result.Details = map[string]string{"email": account.Email, "account_id": account.ID}
+	case 401:
+		result.Status, result.Note = StatusInvalid, "API key not valid"
+	case 403:
+		var refusal struct {
+			ID string `json:"id"` // e.g. "forbidden" or "suspended"
+		}
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&refusal)
+		switch refusal.ID {
+		case "suspended":
+			result.Status, result.Note = StatusInvalid, "Account is suspended"
+		case "":
+			result.Status, result.Note = StatusActive, "Key authenticated but may not read the account"
+		default:
+			result.Status, result.Note = StatusActive, fmt.Sprintf("Key authenticated but may not read the account (%s)", refusal.ID)
+			result.Details = map[string]string{"refused": refusal.ID}
+		}
+	case 429:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// verifyMailchimp checks if a Mailchimp API key is valid using the ping endpoint
+// of the datacenter encoded in the key suffix (e.g. "-us6")
+func (v *SecretVerifier) verifyMailchimp(ctx context.Context, apiKey string) *VerificationResult {
+	apiKey = strings.TrimSpace(apiKey)
+
+	idx := strings.LastIndex(apiKey, "-")
+	if idx < 0 {
+		return &VerificationResult{Status: StatusInvalid, Note: "Missing datacenter suffix", VerifiedAt: time.Now()}
+	}
+	datacenter := apiKey[idx+1:]
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://%s.api.mailchimp.com/3.0/ping", datacenter), nil)
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	req.SetBasicAuth("anystring", apiKey)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	switch resp.StatusCode {
+	case 200:
+		var ping struct {
+			HealthStatus string `json:"health_status"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&ping)
+		result.Status, result.Note = StatusActive, fmt.Sprintf("Mailchimp key valid (%s: %s)", datacenter, ping.HealthStatus)
+		result.Details = map[string]string{"datacenter": datacenter}
+	case 401, 403:
+		result.Status, result.Note = StatusInvalid, "API key not valid"
+	case 429:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// verifyDigitalOcean checks if a DigitalOcean token is valid and reports the account
+func (v *SecretVerifier) verifyDigitalOcean(ctx context.Context, token string) *VerificationResult {
+	token = strings.TrimSpace(token)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.digitalocean.com/v2/account", nil)
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	switch resp.StatusCode {
+	case 200:
+		var account struct {
+			Account struct {
+				Email string `json:"email"`
+				UUID  string `json:"uuid"`
+			} `json:"account"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&account)
+		result.Status, result.Note = StatusActive, fmt.Sprintf("DigitalOcean account %s (UUID: %s)", account.Account.Email, account.Account.UUID)
+		result.Details = map[string]string{"email": account.Account.Email, "account_id": account.Account.UUID}
+	case 401:
+		result.Status, result.Note = StatusInvalid, "Token not valid"
+	case 429:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// verifyGitLab checks if a GitLab personal access token is valid on gitlab.com and reports
+// its name, scopes and expiry. Tokens of self-managed instances are unknown there.
+func (v *SecretVerifier) verifyGitLab(ctx context.Context, token string) *VerificationResult {
+	token = strings.TrimSpace(token)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://gitlab.com/api/v4/personal_access_tokens/self", nil)
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	req.Header.Set("PRIVATE-TOKEN", token)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	switch resp.StatusCode {
+	case 200:
+		var self struct {
+			Name      string   `json:"name"`
+			Scopes    []string `json:"scopes"`
+			ExpiresAt string   `json:"expires_at"`
+			UserID    int      `json:"user_id"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&self)
+		scopes := strings.Join(self.Scopes, ", ")
+		result.Status, result.Note = StatusActive, fmt.Sprintf("GitLab token %q (scopes: %s)", self.Name, scopes)
+		result.Details = map[string]string{"token_name": self.Name, "scopes": scopes}
+		if self.UserID != 0 {
+			result.Details["user_id"] = strconv.Itoa(self.UserID)
+		}
+		if self.ExpiresAt != "" {
+			result.Details["expires_at"] = self.ExpiresAt
+		}
+	case 401:
+		result.Status, result.Note = StatusInvalid, "Token not valid on gitlab.com (revoked, expired or from a self-managed instance)"
+	case 429:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// verifyLinode checks if a Linode token is valid and reports the profile
+func (v *SecretVerifier) verifyLinode(ctx context.Context, token string) *VerificationResult {
+	token = strings.TrimSpace(token)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.linode.com/v4/profile", nil)
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	switch resp.StatusCode {
+	case 200:
+		var profile struct {
+			Username string `json:"username"`
+			Email    string `json:"email"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&profile)
+		result.Status, result.Note = StatusActive, fmt.Sprintf("Linode user '%s' (%s)", profile.Username, profile.Email)
+		result.Details = map[string]string{"login": profile.Username, "email": profile.Email}
+	case 401:
+		result.Status, result.Note = StatusInvalid, "Token not valid"
+	case 429:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// verifyFCM checks a Firebase Cloud Messaging server key by posting an empty payload
+// to the legacy send endpoint: 400 means the key authenticated but the payload was
+// rejected (live key), 401 means the key itself was rejected. No message is ever sent.
+func (v *SecretVerifier) verifyFCM(ctx context.Context, serverKey string) *VerificationResult {
+	serverKey = strings.TrimSpace(serverKey)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://fcm.googleapis.com/fcm/send", strings.NewReader("{}"))
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	req.Header.Set("Authorization", "key="+serverKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	switch resp.StatusCode {
+	case 200, 400:
+		result.Status, result.Note = StatusActive, "FCM server key authenticated (can push notifications)"
+	case 401:
+		result.Status, result.Note = StatusInvalid, "Server key not valid"
+	case 429:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// verifyFirebase checks whether a Firebase Realtime Database is world-readable by reading
+// its root without credentials. The read is shallow (top-level keys only), so the data
+// itself is never downloaded; a database that returns data is a critical finding.
+func (v *SecretVerifier) verifyFirebase(ctx context.Context, databaseURL string) *VerificationResult {
+	endpoint := strings.TrimRight(strings.TrimSpace(databaseURL), "/") + "/.json?shallow=true"
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	switch resp.StatusCode {
+	case 200:
+		var keys map[string]interface{}
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		_ = json.Unmarshal(body, &keys)
+		result.Details = map[string]string{"access": "public_read", "top_level_keys": strconv.Itoa(len(keys))}
+		if len(keys) == 0 {
+			result.Status, result.Note = StatusActive, "Database is world-readable (currently empty)"
+			result.Severity = "high"
+			break
+		}
+		result.Status, result.Note = StatusActive, fmt.Sprintf("Database is world-readable (%d top-level keys)", len(keys))
+		result.Severity = "critical"
+	case 401, 403:
+		result.Status, result.Note = StatusInvalid, "Rules deny public reads"
+	case 404, 423:
+		result.Status, result.Note = StatusInvalid, "Database not found or deactivated"
+	case 429:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// verifyDatadog checks if a Datadog API key is valid
+func (v *SecretVerifier) verifyDatadog(ctx context.Context, apiKey string) *VerificationResult {
+	apiKey = strings.TrimSpace(apiKey)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.datadoghq.com/api/v1/validate", nil)
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	req.Header.Set("DD-API-KEY", apiKey)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	switch resp.StatusCode {
+	case 200:
+		var validate struct {
+			Valid bool `json:"valid"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&validate)
+		if validate.Valid {
+			result.Status, result.Note = StatusActive, "Datadog API key is valid (US1 site)"
+		} else {
+			result.Status, result.Note = StatusInvalid, "Key is not valid"
+		}
+	case 403:
+		result.Status, result.Note = StatusInvalid, "Key is not valid for the US1 site"
+	case 429:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// verifyNewRelic checks if a New Relic user or REST API key is valid
+func (v *SecretVerifier) verifyNewRelic(ctx context.Context, apiKey string) *VerificationResult {
+	apiKey = strings.TrimSpace(apiKey)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.newrelic.com/v2/users.json", nil)
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	req.Header.Set("X-Api-Key", apiKey)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	switch resp.StatusCode {
+	case 200:
+		var users struct {
+			Users []struct {
+				Email string `json:"email"`
+			} `json:"users"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&users)
+		result.Status, result.Note = StatusActive, fmt.Sprintf("New Relic key can list %d user(s)", len(users.Users))
+	case 401, 403:
+		result.Status, result.Note = StatusInvalid, "Key is not valid"
+	case 429:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// verifyCloudflare checks if a Cloudflare API token is valid
+func (v *SecretVerifier) verifyCloudflare(ctx context.Context, token string) *VerificationResult {
+	token = strings.TrimSpace(token)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.cloudflare.com/client/v4/user/tokens/verify", nil)
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	switch resp.StatusCode {
+	case 200:
+		var verify struct {
+			Result struct {
+				Status    string `json:"status"`
+				ExpiresOn string `json:"expires_on"`
+			} `json:"result"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&verify)
+		if verify.Result.Status != "active" {
+			result.Status, result.Note = StatusInvalid, fmt.Sprintf("Token is %s", verify.Result.Status)
+			break
+		}
+		result.Status, result.Note = StatusActive, "Cloudflare API token is valid"
+		if verify.Result.ExpiresOn != "" {
+			result.Note += " (expires " + verify.Result.ExpiresOn + ")"
+			result.Details = map[string]string{"expires_at": verify.Result.ExpiresOn}
+		}
+	case 400, 401, 403:
+		result.Status, result.Note = StatusInvalid, "Token not valid"
+	case 429:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// verifyCloudflareGlobalKey checks an "email:key" Cloudflare Global API key pair
+func (v *SecretVerifier) verifyCloudflareGlobalKey(ctx context.Context, credential string) *VerificationResult {
+	email, key, ok := strings.Cut(strings.TrimSpace(credential), ":")
+	if !ok {
+		return &VerificationResult{
+			Status:     StatusUnsupported,
+			Note:       "Valid format, account email unknown",
+			VerifiedAt: time.Now(),
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.cloudflare.com/client/v4/user", nil)
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	req.Header.Set("X-Auth-Email", email)
+	req.Header.Set("X-Auth-Key", key)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	switch resp.StatusCode {
+	case 200:
+		result.Status, result.Note = StatusActive, fmt.Sprintf("Cloudflare Global API key for %s (full account access)", email)
+		result.Details = map[string]string{"email": email}
+	case 400, 401, 403:
+		result.Status, result.Note = StatusInvalid, "Key not valid"
+	case 429:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// verifySquare checks a Square access token against production, falling back to the
+// sandbox environment so test-mode tokens are labelled as such
+func (v *SecretVerifier) verifySquare(ctx context.Context, token string) *VerificationResult {
+	token = strings.TrimSpace(token)
+
+	var result *VerificationResult
+	for _, env := range []struct{ name, host string }{
+		{"production", "connect.squareup.com"},
+		{"sandbox", "connect.squareupsandbox.com"},
+	} {
+		req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://%s/v2/locations", env.host), nil)
+		if err != nil {
+			return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+		}
+
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := v.httpClient.Do(req)
+		if err != nil {
+			return requestFailed(err)
+		}
+
+		result = &VerificationResult{
+			StatusCode: resp.StatusCode,
+			VerifiedAt: time.Now(),
+		}
+
+		switch resp.StatusCode {
+		case 200:
+			var locations struct {
+				Locations []struct {
+					Name string `json:"name"`
+				} `json:"locations"`
+			}
+			_ = json.NewDecoder(resp.Body).Decode(&locations)
+			resp.Body.Close()
+			result.Status, result.Note = StatusActive, fmt.Sprintf("Square %s token (%d location(s))", env.name, len(locations.Locations))
+			result.Details = map[string]string{"environment": env.name}
+			return result
+		case 401:
+			resp.Body.Close()
+			result.Status, result.Note = StatusInvalid, "Token not valid"
+			continue // Try the sandbox before giving up
+		case 429:
+			resp.Body.Close()
+			result.Status = StatusRateLimited
+			return result
+		default:
+			resp.Body.Close()
+			result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+			return result
+		}
+	}
+
+	return result
+}
+
+// verifyPayPal checks a PayPal "clientID:secret" pair with a client-credentials token grant
+// against the live or sandbox host it was found with
+func (v *SecretVerifier) verifyPayPal(ctx context.Context, credential, host string) *VerificationResult {
+	clientID, clientSecret, ok := strings.Cut(strings.TrimSpace(credential), ":")
+	if !ok {
+		return &VerificationResult{Status: StatusInvalid, Note: "Expected client ID and secret", VerifiedAt: time.Now()}
+	}
+	if host == "" {
+		host = "api-m.paypal.com"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("https://%s/v1/oauth2/token", host),
+		strings.NewReader("grant_type=client_credentials"))
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	req.SetBasicAuth(clientID, clientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	environment := "live"
+	if strings.Contains(host, "sandbox") {
+		environment = "sandbox"
 	}
 
-	// Try to decode the payload (base64url)
-	payload := parts[1]
-	// Add padding if needed
-	if m := len(payload) % 4; m != 0 {
-		payload += strings.Repeat("=", 4-m)
+	switch resp.StatusCode {
+	case 200:
+		var token struct {
+			AppID string `json:"app_id"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&token)
+		result.Status, result.Note = StatusActive, fmt.Sprintf("PayPal %s app %s", environment, token.AppID)
+		result.Details = map[string]string{"environment": environment, "app_id": token.AppID}
+	case 401:
+		result.Status, result.Note = StatusInvalid, "Client credentials not valid"
+	case 429:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// verifyShopify checks a Shopify access token against the shop it was found with
+func (v *SecretVerifier) verifyShopify(ctx context.Context, token, shop string) *VerificationResult {
+	token = strings.TrimSpace(token)
+
+	if shop == "" {
+		return &VerificationResult{
+			Status:     StatusUnsupported,
+			Note:       "Valid format, shop domain unknown",
+			VerifiedAt: time.Now(),
+		}
 	}
 
-	decoded, err := base64.RawURLEncoding.DecodeString(payload)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://%s/admin/api/2024-01/shop.json", shop), nil)
 	if err != nil {
-		// Try standard base64
-		decoded, err = base64.StdEncoding.DecodeString(payload)
-		if err != nil {
-			return &VerificationResult{
-				IsValid:    false,
-				Message:    "❌ Cannot decode JWT payload",
-				VerifiedAt: time.Now(),
-			}
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	req.Header.Set("X-Shopify-Access-Token", token)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	switch resp.StatusCode {
+	case 200:
+		var shopInfo struct {
+			Shop struct {
+				Name  string `json:"name"`
+				Email string `json:"email"`
+				Plan  string `json:"plan_name"`
+			} `json:"shop"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&shopInfo)
+		result.Status, result.Note = StatusActive, fmt.Sprintf("Shopify shop '%s' (%s, plan: %s)", shopInfo.Shop.Name, shopInfo.Shop.Email, shopInfo.Shop.Plan)
+		result.Details = map[string]string{"shop": shopInfo.Shop.Name, "email": shopInfo.Shop.Email, "plan": shopInfo.Shop.Plan}
+	case 401:
+		result.Status, result.Note = StatusInvalid, "Token not valid for "+shop
+	case 403:
+		result.Status, result.Note = StatusActive, "Token valid for "+shop+" but lacks read_shop access"
+	case 404:
+		result.Status, result.Note = StatusInvalid, "Shop "+shop+" not found"
+	case 429:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// verifyPyPI validates the PyPI token macaroon and, with the upload check enabled, checks
+// it against the legacy upload endpoint with an empty form, which is rejected before
+// anything could be uploaded
+func (v *SecretVerifier) verifyPyPI(ctx context.Context, token string) *VerificationResult {
+	token = strings.TrimSpace(token)
+
+	// Structural check: the macaroon must decode and name pypi.org as its location
+	// (decode whole 4-char groups only, the token may have been cut short by the pattern)
+	encoded := strings.TrimRight(strings.TrimPrefix(token, "pypi-"), "=")
+	macaroon, err := base64.RawURLEncoding.DecodeString(encoded[:len(encoded)/4*4])
+	if err != nil || !strings.Contains(string(macaroon), "pypi.org") {
+		return &VerificationResult{Status: StatusInvalid, Note: "Malformed PyPI macaroon", VerifiedAt: time.Now()}
+	}
+	if !v.pypiUploadCheck {
+		return &VerificationResult{
+			Status:     StatusUnsupported,
+			Note:       "Valid PyPI macaroon, upload check disabled (deep_scan.verify_pypi_upload)",
+			VerifiedAt: time.Now(),
+		}
+	}
+
+	form := strings.NewReader(":action=file_upload&protocol_version=1")
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://upload.pypi.org/legacy/", form)
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	req.SetBasicAuth("__token__", token)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	switch resp.StatusCode {
+	case 400:
+		// Authenticated, then rejected for the missing package
+		result.Status, result.Note = StatusActive, "Token accepted by the PyPI upload endpoint"
+	case 401, 403:
+		result.Status, result.Note = StatusInvalid, "Token rejected by PyPI"
+	case 429:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// verifyDockerHub checks if a Docker Hub personal access token is valid
+func (v *SecretVerifier) verifyDockerHub(ctx context.Context, token string) *VerificationResult {
+	token = strings.TrimSpace(token)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://hub.docker.com/v2/user/", nil)
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	switch resp.StatusCode {
+	case 200:
+		var user struct {
+			Username string `json:"username"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&user)
+		result.Status, result.Note = StatusActive, fmt.Sprintf("Docker Hub token for '%s'", user.Username)
+		result.Details = map[string]string{"login": user.Username}
+	case 401, 403:
+		result.Status, result.Note = StatusInvalid, "Token not valid"
+	case 429:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// verifyNPM checks if an npm access token is valid and reports the user it belongs to
+func (v *SecretVerifier) verifyNPM(ctx context.Context, token string) *VerificationResult {
+	token = strings.TrimSpace(token)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://registry.npmjs.org/-/whoami", nil)
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	switch resp.StatusCode {
+	case 200:
+		var user struct {
+			Username string `json:"username"`
 		}
+		_ = json.NewDecoder(resp.Body).Decode(&user)
+		result.Status, result.Note = StatusActive, fmt.Sprintf("npm token for '%s'", user.Username)
+		result.Details = map[string]string{"login": user.Username}
+	case 401, 403:
+		result.Status, result.Note = StatusInvalid, "Token not valid"
+	case 429:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
 	}
 
-	// Parse JSON
-	var claims map[string]interface{}
-	if err := json.Unmarshal(decoded, &claims); err != nil {
+	return result
+}
+
+// verifyJWT analyzes a JWT. An HMAC-signed token counts as verified when the scanner found
+// its key elsewhere in the collection; an RS/ES-signed token whose iss is an HTTPS URL is
+// checked against the keys that issuer publishes. Otherwise only structure and expiry are
+// checked.
+func (v *SecretVerifier) verifyJWT(ctx context.Context, secret SecretMatch) *VerificationResult {
+	claims, err := jwtClaims(secret.RawValue)
+	if err != nil {
+		note := "Invalid JWT payload"
+		switch {
+		case errors.Is(err, errJWTMalformed):
+			note = "Malformed JWT"
+		case errors.Is(err, errJWTEncoding):
+			note = "Cannot decode JWT payload"
+		}
 		return &VerificationResult{
-			IsValid:    false,
-			Message:    "❌ Invalid JWT payload",
+			Status:     StatusInvalid,
+			Note:       note,
 			VerifiedAt: time.Now(),
 		}
 	}
 
-	// Check expiration
+	expiry := "no expiration"
+	var expires time.Time
+	details := make(map[string]string)
+	alg := jwtAlgorithm(secret.RawValue)
+	if alg != "" {
+		details["alg"] = alg
+	}
 	if exp, ok := claims["exp"].(float64); ok {
 		expTime := time.Unix(int64(exp), 0)
+		expires = expTime
+		details["expires_at"] = expTime.UTC().Format(time.RFC3339)
 		if time.Now().After(expTime) {
 			return &VerificationResult{
-				IsValid:    false,
-				Message:    fmt.Sprintf("⏰ EXPIRED - Token expired at %s", expTime.Format("2006-01-02 15:04")),
+				Status:     StatusExpired,
+				Note:       fmt.Sprintf("Token expired at %s", expTime.Format(jwtTimeFormat)),
+				VerifiedAt: time.Now(),
+				Details:    details,
+			}
+		}
+		expiry = "expires at " + expTime.Format(jwtTimeFormat)
+	}
+
+	if jwtHMACs[alg] != nil {
+		if signature := secret.Metadata["signature"]; strings.HasPrefix(signature, "verified") {
+			details["signature"] = "valid"
+			return &VerificationResult{
+				Status:     StatusActive,
+				Note:       fmt.Sprintf("Signature %s, %s", signature, expiry),
 				VerifiedAt: time.Now(),
+				Details:    details,
 			}
 		}
+		// Only the holder of the shared secret can check an HMAC signature
 		return &VerificationResult{
-			IsValid:    true,
-			Message:    fmt.Sprintf("⚠️  VALID structure - Expires at %s (signature not verified)", expTime.Format("2006-01-02 15:04")),
+			Status:     StatusUnsupported,
+			Note:       fmt.Sprintf("Cannot verify (symmetric %s signature), %s", alg, expiry),
 			VerifiedAt: time.Now(),
+			Details:    details,
 		}
 	}
 
-	return &VerificationResult{
-		IsValid:    true,
-		Message:    "⚠️  VALID structure (no expiration, signature not verified)",
+	// A token whose signature nobody checked proves nothing about who issued it, so a
+	// well-formed one is only reported as not verifiable
+	issuer, ok := issuerURL(claimString(claims["iss"]))
+	if !ok || jwtAsymmetric[alg].kty == "" {
+		note := fmt.Sprintf("Valid structure, %s (structure only, signature not verified)", expiry)
+		if strings.EqualFold(alg, "none") {
+			note = fmt.Sprintf("Valid structure, %s (unsigned, alg none)", expiry)
+		}
+		return &VerificationResult{
+			Status:     StatusUnsupported,
+			Note:       note,
+			VerifiedAt: time.Now(),
+			Details:    details,
+		}
+	}
+
+	details["issuer"] = issuer.String()
+	kid, err := v.checkJWTIssuerSignature(ctx, secret.RawValue, issuer)
+	if kid != "" {
+		details["kid"] = kid
+	}
+	switch {
+	case errors.Is(err, errPrivateAddress):
+		return &VerificationResult{
+			Status:     StatusUnsupported,
+			Note:       fmt.Sprintf("Valid structure, %s (issuer %s resolves to a private address - not contacted)", expiry, issuer.Host),
+			VerifiedAt: time.Now(),
+			Details:    details,
+		}
+	case err == nil:
+		details["signature"] = "valid"
+		return &VerificationResult{
+			Status:     StatusActive,
+			Note:       fmt.Sprintf("Signature valid against issuer %s, %s", issuer.Host, expiry),
+			StatusCode: http.StatusOK, // The issuer's published keys
+			VerifiedAt: time.Now(),
+			ExpiresAt:  expires,
+			Details:    details,
+		}
+	case errors.Is(err, errJWTSignature), errors.Is(err, errJWTNoKey):
+		// Forged, or signed with a key the issuer has since rotated out
+		details["signature"] = "invalid"
+		note := fmt.Sprintf("Signature invalid, not signed by issuer %s", issuer.Host)
+		if errors.Is(err, errJWTNoKey) {
+			note = fmt.Sprintf("Signature invalid, issuer %s publishes no key %q", issuer.Host, kid)
+		}
+		return &VerificationResult{
+			Status:     StatusInvalid,
+			Note:       note,
+			StatusCode: http.StatusOK, // The issuer's published keys
+			VerifiedAt: time.Now(),
+			ExpiresAt:  expires,
+			Details:    details,
+		}
+	}
+
+	result := requestFailed(err)
+	if result.Status == StatusError {
+		result.Note = fmt.Sprintf("Could not check signature against issuer %s: %v", issuer.Host, err)
+	}
+	result.Details = details
+	return result
+}
+
+// azureStorageDomain is the domain every Azure Storage service endpoint is under
+const azureStorageDomain = ".core.windows.net"
+
+// azureSASEndpointPattern splits a SAS connection string into its service endpoint and token
+var azureSASEndpointPattern = regexp.MustCompile(`(?i)(?:Blob|Queue|Table|File)Endpoint=(https://[a-z0-9.-]+)/?;.*?SharedAccessSignature=(\S+)`)
+
+// verifyAzureSAS checks a SAS connection string by listing the service with its token.
+// A SAS that authenticates but lacks list permission is still reported as live. The
+// endpoint comes from the scanned collection, so the token is only ever sent to an Azure
+// Storage host (*.core.windows.net) on a public address.
+func (v *SecretVerifier) verifyAzureSAS(ctx context.Context, raw string) *VerificationResult {
+	m := azureSASEndpointPattern.FindStringSubmatch(strings.TrimSpace(raw))
+	if m == nil {
+		return &VerificationResult{Status: StatusInvalid, Note: "Endpoint or signature not found", VerifiedAt: time.Now()}
+	}
+	endpoint, sas := m[1], strings.TrimPrefix(strings.TrimSuffix(m[2], ";"), "?")
+
+	host := strings.ToLower(strings.TrimPrefix(endpoint, "https://"))
+	if !strings.HasSuffix(host, azureStorageDomain) {
+		return &VerificationResult{
+			Status:     StatusUnsupported,
+			Note:       fmt.Sprintf("Endpoint %s is not an Azure Storage host - not contacted", host),
+			VerifiedAt: time.Now(),
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint+"/?comp=list&maxresults=1&"+sas, nil)
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	resp, err := v.issuerClient().Do(req)
+	if errors.Is(err, errPrivateAddress) {
+		return &VerificationResult{
+			Status:     StatusUnsupported,
+			Note:       fmt.Sprintf("Endpoint %s resolves to a private address - not contacted", host),
+			VerifiedAt: time.Now(),
+		}
+	}
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	switch resp.StatusCode {
+	case 200:
+		result.Status, result.Note = StatusActive, fmt.Sprintf("SAS grants list access to %s", endpoint)
+	case 403:
+		var storageErr struct {
+			Code string `xml:"Code"`
+		}
+		_ = xml.NewDecoder(resp.Body).Decode(&storageErr)
+		switch storageErr.Code {
+		case "AuthorizationPermissionMismatch", "AuthorizationResourceTypeMismatch", "AuthorizationServiceMismatch":
+			result.Status, result.Note = StatusActive, fmt.Sprintf("SAS is valid for %s but cannot list (%s)", endpoint, storageErr.Code)
+		default:
+			result.Status, result.Note = StatusInvalid, "Signature rejected or expired"
+		}
+	case 429, 503:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// azureKeyPattern extracts the base64 account key from a connection string or key assignment
+var azureKeyPattern = regexp.MustCompile(`[A-Za-z0-9+/]{86}==`)
+
+// verifyAzureStorage checks the structure of an Azure Storage account key and, when the
+// account name is part of the match, lists containers using SharedKey authentication
+func (v *SecretVerifier) verifyAzureStorage(ctx context.Context, raw string) *VerificationResult {
+	encodedKey := azureKeyPattern.FindString(raw)
+	if encodedKey == "" {
+		return &VerificationResult{Status: StatusInvalid, Note: "Account key not found", VerifiedAt: time.Now()}
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil || len(key) != 64 {
+		return &VerificationResult{Status: StatusInvalid, Note: "Account key is not a 64-byte base64 value", VerifiedAt: time.Now()}
+	}
+
+	// Without the account name we can only vouch for the key format
+	account := ""
+	if m := azureAccountNamePattern.FindStringSubmatch(raw); m != nil {
+		account = strings.ToLower(m[1])
+	}
+	if account == "" {
+		return &VerificationResult{
+			Status:     StatusUnsupported,
+			Note:       "Valid format, account name unknown",
+			VerifiedAt: time.Now(),
+		}
+	}
+
+	endpoint := fmt.Sprintf("https://%s.blob.core.windows.net/?comp=list&maxresults=1", account)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	date := time.Now().UTC().Format(http.TimeFormat)
+	version := "2021-08-06"
+	req.Header.Set("x-ms-date", date)
+	req.Header.Set("x-ms-version", version)
+
+	// SharedKey string-to-sign: 11 empty standard headers for a GET, then canonicalized headers and resource
+	stringToSign := "GET\n" + strings.Repeat("\n", 11) +
+		"x-ms-date:" + date + "\n" +
+		"x-ms-version:" + version + "\n" +
+		"/" + account + "/\ncomp:list\nmaxresults:1"
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(stringToSign))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	req.Header.Set("Authorization", fmt.Sprintf("SharedKey %s:%s", account, signature))
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
+		VerifiedAt: time.Now(),
+	}
+
+	switch resp.StatusCode {
+	case 200:
+		result.Status, result.Note = StatusActive, fmt.Sprintf("Account key grants access to storage account '%s'", account)
+		result.Details = map[string]string{"account": account}
+	case 403:
+		result.Status, result.Note = StatusInvalid, "Authentication failed for storage account"
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+// verifyTwilio checks a Twilio "SID:AuthToken" pair by fetching the account resource
+func (v *SecretVerifier) verifyTwilio(ctx context.Context, credential string) *VerificationResult {
+	sid, token, found := strings.Cut(strings.TrimSpace(credential), ":")
+	if !found || token == "" {
+		return &VerificationResult{
+			Status:     StatusUnsupported,
+			Note:       "Account SID alone cannot be verified (no auth token in the same request)",
+			VerifiedAt: time.Now(),
+		}
+	}
+
+	endpoint := fmt.Sprintf("https://api.twilio.com/2010-04-01/Accounts/%s.json", sid)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return &VerificationResult{Status: StatusError, Note: "Failed to create request", VerifiedAt: time.Now()}
+	}
+
+	req.SetBasicAuth(sid, token)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return requestFailed(err)
+	}
+	defer resp.Body.Close()
+
+	result := &VerificationResult{
+		StatusCode: resp.StatusCode,
 		VerifiedAt: time.Now(),
 	}
+
+	switch resp.StatusCode {
+	case 200:
+		var account struct {
+			FriendlyName string `json:"friendly_name"`
+			Status       string `json:"status"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&account)
+		result.Status, result.Note = StatusActive, fmt.Sprintf("Twilio account '%s' (%s)", account.FriendlyName, account.Status)
+		result.Details = map[string]string{"account": account.FriendlyName, "account_status": account.Status}
+	case 401:
+		result.Status, result.Note = StatusInvalid, "Credentials rejected"
+	case 429:
+		result.Status = StatusRateLimited
+	default:
+		result.Status, result.Note = StatusError, fmt.Sprintf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	return result
 }
@@ -0,0 +1,134 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newInternalTokenVerifier returns a test verifier checking "Internal Token" secrets
+// against server with a custom verifier
+func newInternalTokenVerifier(t *testing.T, server *countingServer) *SecretVerifier {
+	t.Helper()
+	custom, err := NewCustomVerifier(CustomVerifier{URL: server.URL + "/check?token={{secret}}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := newTestVerifier(t)
+	v.SetCustomVerifier("Internal Token", custom)
+	return v
+}
+
+func internalTokens(values ...string) []SecretMatch {
+	secrets := make([]SecretMatch, len(values))
+	for i, value := range values {
+		secrets[i] = SecretMatch{Type: "Internal Token", RawValue: value}
+	}
+	return secrets
+}
+
+func TestVerifyAllDeduplicates(t *testing.T) {
+	tests := []struct {
+		name         string
+		values       []string
+		wantRequests int
+	}{
+		{"all distinct", []string{"tok-a", "tok-b", "tok-c"}, 3},
+		{"copies of one", []string{"tok-a", "tok-a", "tok-a", "tok-a"}, 1},
+		{"mixed", []string{"tok-a", "tok-b", "tok-a", "tok-b", "tok-a", "tok-c"}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newCountingServer(t, false, func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(20 * time.Millisecond) // Keep copies in flight together
+			})
+			v := newInternalTokenVerifier(t, server)
+			v.SetWorkers(len(tt.values))
+
+			results := v.VerifyAll(context.Background(), internalTokens(tt.values...))
+			if got := len(server.requested()); got != tt.wantRequests {
+				t.Errorf("sent %d requests, want %d", got, tt.wantRequests)
+			}
+			for i, result := range results {
+				if result == nil || result.Status != StatusActive {
+					t.Fatalf("result %d = %+v, want active", i, result)
+				}
+				for j := range i {
+					if tt.values[i] == tt.values[j] && results[i] != results[j] {
+						t.Errorf("results %d and %d of the same secret differ", j, i)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyAllBoundsConcurrency(t *testing.T) {
+	for _, workers := range []int{1, 2, 4} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			var inFlight, maxInFlight atomic.Int32
+			server := newCountingServer(t, false, func(w http.ResponseWriter, r *http.Request) {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					seen := maxInFlight.Load()
+					if n <= seen || maxInFlight.CompareAndSwap(seen, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+			})
+			v := newInternalTokenVerifier(t, server)
+			v.SetWorkers(workers)
+
+			values := make([]string, 8)
+			for i := range values {
+				values[i] = fmt.Sprintf("tok-%d", i)
+			}
+			results := v.VerifyAll(context.Background(), internalTokens(values...))
+			for i, result := range results {
+				if result == nil {
+					t.Fatalf("result %d missing", i)
+				}
+			}
+			if got := int(maxInFlight.Load()); got > workers {
+				t.Errorf("%d requests in flight at once, want at most %d", got, workers)
+			}
+		})
+	}
+}
+
+func TestVerifyAllPacesRequestsToOneHost(t *testing.T) {
+	const interval = 40 * time.Millisecond
+
+	var mu sync.Mutex
+	var started []time.Time
+	server := newCountingServer(t, false, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		started = append(started, time.Now())
+		mu.Unlock()
+	})
+	v := newInternalTokenVerifier(t, server)
+	v.httpClient.pacer.interval = interval
+	v.SetWorkers(4)
+
+	v.VerifyAll(context.Background(), internalTokens("tok-a", "tok-b", "tok-c", "tok-d"))
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(started) != 4 {
+		t.Fatalf("sent %d requests, want 4", len(started))
+	}
+	sort.Slice(started, func(i, j int) bool { return started[i].Before(started[j]) })
+	for i := 1; i < len(started); i++ {
+		// A little slack for the server seeing requests later than they were sent
+		if gap := started[i].Sub(started[i-1]); gap < interval-10*time.Millisecond {
+			t.Errorf("request %d started %s after the previous one, want about %s", i, gap, interval)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"* * * FOO *",
+		"1,,2 * * * *",
+	}
+	for _, expr := range tests {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", expr)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	// 2024-01-01 is a Monday
+	from := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"every minute", "* * * * *", from, time.Date(2024, 1, 1, 10, 31, 0, 0, time.UTC)},
+		{"strictly after", "30 10 * * *", from, time.Date(2024, 1, 2, 10, 30, 0, 0, time.UTC)},
+		{"seconds truncated", "31 10 * * *", from.Add(59 * time.Second), time.Date(2024, 1, 1, 10, 31, 0, 0, time.UTC)},
+		{"step", "*/20 * * * *", from, time.Date(2024, 1, 1, 10, 40, 0, 0, time.UTC)},
+		{"step from a start", "5/15 * * * *", from, time.Date(2024, 1, 1, 10, 35, 0, 0, time.UTC)},
+		{"range with step", "0-30/10 12 * * *", from, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{"list", "0 9,17 * * *", from, time.Date(2024, 1, 1, 17, 0, 0, 0, time.UTC)},
+		{"hour range", "0 22-23 * * *", from, time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC)},
+		{"weekday range by name", "0 2 * * MON-FRI", time.Date(2024, 1, 5, 3, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 2, 0, 0, 0, time.UTC)},
+		{"weekday names any case", "0 2 * * sat", from, time.Date(2024, 1, 6, 2, 0, 0, 0, time.UTC)},
+		{"sunday as 0", "0 0 * * 0", from, time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)},
+		{"sunday as 7", "0 0 * * 7", from, time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)},
+		{"day of month", "0 0 15 * *", from, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"month name", "0 0 1 MAR *", from, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"month range", "0 0 1 JUN-AUG *", from, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either may match (the 15th or a Friday)
+		{"day of month or week", "0 0 15 * FRI", from, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"day of month or week, month day first", "0 0 3 * FRI", from, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+		// Only one restricted: it alone decides
+		{"day of week only", "0 0 * * FRI", from, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+		// A stepped day field counts as unrestricted, as in cron
+		{"stepped day of month with weekday", "0 0 */2 * MON", from, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", from, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"never", "0 0 30 2 *", from, time.Time{}},
+		{"hourly", "@hourly", from, time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)},
+		{"daily", "@daily", from, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"weekly", "@weekly", from, time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)},
+		{"monthly", "@monthly", from, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"yearly", "@yearly", from, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"year rollover", "0 0 * * *", time.Date(2024, 12, 31, 23, 59, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.expr, err)
+			}
+			if got := s.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Next(%s) = %s, want %s", tt.from, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,429 @@
+// Package toml decodes the subset of TOML read by -config files ending in .toml and by
+// Gitleaks rule files (deep_scan.pattern_files), without a third-party dependency.
+package toml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Parse decodes a TOML document into nested maps: tables become
+// map[string]interface{}, arrays (and arrays of tables) []interface{}, numbers float64.
+// Dates and quoted keys containing a dot are not supported.
+func Parse(data string) (map[string]interface{}, error) {
+	p := &parser{data: data, line: 1}
+	root := make(map[string]interface{})
+	table := root
+
+	for {
+		p.skipBlank()
+		if p.done() {
+			return root, nil
+		}
+
+		if p.peek() == '[' {
+			arrayTable := strings.HasPrefix(p.rest(), "[[")
+			name, err := p.header(arrayTable)
+			if err != nil {
+				return nil, err
+			}
+			if table, err = p.table(root, strings.Split(name, "."), arrayTable); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		key, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+		value, err := p.value()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		if err := p.set(table, key, value); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// set assigns a value to a (possibly dotted) key of a table
+func (p *parser) set(table map[string]interface{}, key string, value interface{}) error {
+	path := strings.Split(key, ".")
+	parent, err := p.table(table, path[:len(path)-1], false)
+	if err != nil {
+		return err
+	}
+	name := path[len(path)-1]
+	if _, exists := parent[name]; exists {
+		return p.errorf("duplicate key %q", key)
+	}
+	parent[name] = value
+	return nil
+}
+
+// table returns the table at path below parent, creating missing tables. Paths through an
+// array of tables use its latest table; with arrayTable set, a new table is appended to
+// the array at path instead.
+func (p *parser) table(parent map[string]interface{}, path []string, arrayTable bool) (map[string]interface{}, error) {
+	for i, name := range path {
+		if arrayTable && i == len(path)-1 {
+			list, ok := parent[name].([]interface{})
+			if !ok && parent[name] != nil {
+				return nil, p.errorf("%q is not an array of tables", name)
+			}
+			table := make(map[string]interface{})
+			parent[name] = append(list, table)
+			return table, nil
+		}
+
+		switch existing := parent[name].(type) {
+		case nil:
+			table := make(map[string]interface{})
+			parent[name] = table
+			parent = table
+		case map[string]interface{}:
+			parent = existing
+		case []interface{}:
+			var table map[string]interface{}
+			if len(existing) > 0 {
+				table, _ = existing[len(existing)-1].(map[string]interface{})
+			}
+			if table == nil {
+				return nil, p.errorf("%q is not a table", name)
+			}
+			parent = table
+		default:
+			return nil, p.errorf("%q is not a table", name)
+		}
+	}
+	return parent, nil
+}
+
+// parser is a small cursor over a TOML document
+type parser struct {
+	data string
+	pos  int
+	line int
+}
+
+func (p *parser) done() bool   { return p.pos >= len(p.data) }
+func (p *parser) rest() string { return p.data[p.pos:] }
+
+func (p *parser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.data[p.pos]
+}
+
+func (p *parser) advance(n int) {
+	p.line += strings.Count(p.data[p.pos:p.pos+n], "\n")
+	p.pos += n
+}
+
+// skipSpace skips spaces and tabs, plus newlines and comments when multiline is set
+func (p *parser) skipSpace(multiline bool) {
+	for !p.done() {
+		switch c := p.peek(); {
+		case c == ' ' || c == '\t' || c == '\r':
+			p.advance(1)
+		case c == '\n' && multiline:
+			p.advance(1)
+		case c == '#':
+			end := strings.IndexByte(p.rest(), '\n')
+			if end < 0 {
+				end = len(p.rest())
+			}
+			p.advance(end)
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) skipBlank() { p.skipSpace(true) }
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+// header reads a [table] or [[array.of.tables]] header and returns its dotted name
+func (p *parser) header(arrayTable bool) (string, error) {
+	open, closing := "[", "]"
+	if arrayTable {
+		open, closing = "[[", "]]"
+	}
+	p.advance(len(open))
+
+	end := strings.Index(p.rest(), closing)
+	if end < 0 || strings.Contains(p.rest()[:end], "\n") {
+		return "", p.errorf("unterminated table header")
+	}
+	var parts []string
+	for _, part := range strings.Split(p.rest()[:end], ".") {
+		parts = append(parts, strings.Trim(strings.TrimSpace(part), `"'`))
+	}
+	p.advance(end + len(closing))
+	return strings.Join(parts, "."), nil
+}
+
+// key reads a bare or quoted key (dotted keys are joined) and the following '='
+func (p *parser) key() (string, error) {
+	var parts []string
+	for {
+		p.skipSpace(false)
+		switch p.peek() {
+		case '"', '\'':
+			part, err := p.str()
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, part)
+		default:
+			start := p.pos
+			for !p.done() && isBareKeyChar(p.peek()) {
+				p.advance(1)
+			}
+			if p.pos == start {
+				return "", p.errorf("expected a key, found %q", p.peek())
+			}
+			parts = append(parts, p.data[start:p.pos])
+		}
+
+		p.skipSpace(false)
+		if p.peek() != '.' {
+			break
+		}
+		p.advance(1)
+	}
+
+	if p.peek() != '=' {
+		return "", p.errorf("expected '=' after key %q", strings.Join(parts, "."))
+	}
+	p.advance(1)
+	p.skipSpace(false)
+	return strings.Join(parts, "."), nil
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+// value reads a string, array, inline table, number or boolean. Numbers are float64,
+// inline tables map[string]interface{}.
+func (p *parser) value() (interface{}, error) {
+	switch c := p.peek(); {
+	case c == '"' || c == '\'':
+		return p.str()
+
+	case c == '[':
+		p.advance(1)
+		var list []interface{}
+		for {
+			p.skipBlank()
+			if p.peek() == ']' {
+				p.advance(1)
+				return list, nil
+			}
+			item, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+			p.skipBlank()
+			switch p.peek() {
+			case ',':
+				p.advance(1)
+			case ']':
+			default:
+				return nil, p.errorf("expected ',' or ']' in array")
+			}
+		}
+
+	case c == '{':
+		p.advance(1)
+		table := make(map[string]interface{})
+		for {
+			p.skipSpace(false)
+			if p.peek() == '}' {
+				p.advance(1)
+				return table, nil
+			}
+			key, err := p.key()
+			if err != nil {
+				return nil, err
+			}
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.set(table, key, value); err != nil {
+				return nil, err
+			}
+			p.skipSpace(false)
+			if p.peek() == ',' {
+				p.advance(1)
+			}
+		}
+
+	default:
+		start := p.pos
+		for !p.done() && !strings.ContainsRune(" \t\r\n,]}#", rune(p.peek())) {
+			p.advance(1)
+		}
+		token := p.data[start:p.pos]
+		switch token {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		number, err := strconv.ParseFloat(strings.ReplaceAll(token, "_", ""), 64)
+		if err != nil {
+			return nil, p.errorf("unsupported value %q", token)
+		}
+		return number, nil
+	}
+}
+
+// str reads a basic ("..."), literal ('...') or triple-quoted multi-line string
+func (p *parser) str() (string, error) {
+	quote := p.data[p.pos : p.pos+1]
+	literal := quote == "'"
+
+	if delimiter := strings.Repeat(quote, 3); strings.HasPrefix(p.rest(), delimiter) {
+		p.advance(3)
+		if strings.HasPrefix(p.rest(), "\r\n") {
+			p.advance(2) // A newline right after the opening delimiter is trimmed
+		} else if p.peek() == '\n' {
+			p.advance(1)
+		}
+		end := strings.Index(p.rest(), delimiter)
+		for !literal && end > 0 && escapedAt(p.rest(), end) {
+			next := strings.Index(p.rest()[end+1:], delimiter)
+			if next < 0 {
+				end = -1
+				break
+			}
+			end += 1 + next
+		}
+		if end < 0 {
+			return "", p.errorf("unterminated multi-line string")
+		}
+		// Up to two quotes may directly precede the closing delimiter
+		for end+3 < len(p.rest()) && p.rest()[end+3] == quote[0] {
+			end++
+		}
+		raw := p.rest()[:end]
+		p.advance(end + 3)
+		if literal {
+			return raw, nil
+		}
+		return unescape(trimLineContinuations(raw), p)
+	}
+
+	p.advance(1)
+	for i := 0; i < len(p.rest()); i++ {
+		switch p.rest()[i] {
+		case '\n':
+			return "", p.errorf("unterminated string")
+		case '\\':
+			if !literal {
+				i++ // Skip the escaped character
+			}
+		case quote[0]:
+			raw := p.rest()[:i]
+			p.advance(i + 1)
+			if literal {
+				return raw, nil
+			}
+			return unescape(raw, p)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// escapedAt reports whether the character at i is preceded by an odd number of backslashes
+func escapedAt(s string, i int) bool {
+	backslashes := 0
+	for j := i - 1; j >= 0 && s[j] == '\\'; j-- {
+		backslashes++
+	}
+	return backslashes%2 == 1
+}
+
+// trimLineContinuations removes a backslash at the end of a line of a multi-line basic
+// string, along with the whitespace that follows it
+func trimLineContinuations(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && !escapedAt(s, i) {
+			j := i + 1
+			for j < len(s) && (s[j] == ' ' || s[j] == '\t' || s[j] == '\r') {
+				j++
+			}
+			if j < len(s) && s[j] == '\n' {
+				for j < len(s) && strings.ContainsRune(" \t\r\n", rune(s[j])) {
+					j++
+				}
+				i = j - 1
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// unescape resolves the escape sequences of a basic string
+func unescape(s string, p *parser) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		if i >= len(s) {
+			return "", p.errorf("trailing backslash in string")
+		}
+		switch s[i] {
+		case 'b':
+			b.WriteByte('\b')
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'f':
+			b.WriteByte('\f')
+		case 'r':
+			b.WriteByte('\r')
+		case '"', '\\':
+			b.WriteByte(s[i])
+		case 'u', 'U':
+			size := 4
+			if s[i] == 'U' {
+				size = 8
+			}
+			if i+1+size > len(s) {
+				return "", p.errorf("short unicode escape in string")
+			}
+			code, err := strconv.ParseUint(s[i+1:i+1+size], 16, 32)
+			if err != nil || !utf8.ValidRune(rune(code)) {
+				return "", p.errorf("invalid unicode escape in string")
+			}
+			b.WriteRune(rune(code))
+			i += size
+		default:
+			return "", p.errorf("invalid escape \\%c in string", s[i])
+		}
+	}
+	return b.String(), nil
+}
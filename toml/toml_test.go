@@ -0,0 +1,79 @@
+package toml
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want map[string]interface{}
+	}{
+		{"bare values", "a = 1\nb = 2.5\nc = true\nd = false\ne = 1_000\n",
+			map[string]interface{}{"a": 1.0, "b": 2.5, "c": true, "d": false, "e": 1000.0}},
+		{"basic string escapes", `s = "tab\there \"quoted\" \\ \u00e9"`,
+			map[string]interface{}{"s": "tab\there \"quoted\" \\ é"}},
+		{"literal string", `s = 'C:\path\no\escapes'`,
+			map[string]interface{}{"s": `C:\path\no\escapes`}},
+		{"multi-line basic string", "s = \"\"\"\nline one\nline \\\n    two\"\"\"",
+			map[string]interface{}{"s": "line one\nline two"}},
+		{"multi-line literal string", "s = '''\n(?i)key\\s*=\\s*'[a-z]+'\n'''",
+			map[string]interface{}{"s": "(?i)key\\s*=\\s*'[a-z]+'\n"}},
+		{"quotes before the closing delimiter", `s = """say "hi""""`,
+			map[string]interface{}{"s": `say "hi"`}},
+		{"comments", "# header\na = \"x # not a comment\" # trailing\n",
+			map[string]interface{}{"a": "x # not a comment"}},
+		{"arrays", "a = [1, 2,\n  3, # comment\n]\nb = []\nc = [\"x\", ['y']]",
+			map[string]interface{}{"a": []interface{}{1.0, 2.0, 3.0}, "b": []interface{}(nil), "c": []interface{}{"x", []interface{}{"y"}}}},
+		{"inline table", `t = { a = 1, b = "two" }`,
+			map[string]interface{}{"t": map[string]interface{}{"a": 1.0, "b": "two"}}},
+		{"tables and dotted keys", "[server]\nhost = \"x\"\ntls.enabled = true\n[server.limits]\nmax = 3\n",
+			map[string]interface{}{"server": map[string]interface{}{
+				"host":   "x",
+				"tls":    map[string]interface{}{"enabled": true},
+				"limits": map[string]interface{}{"max": 3.0},
+			}}},
+		{"arrays of tables", "[[rules]]\nid = \"a\"\n[rules.allowlist]\nx = 1\n[[rules]]\nid = \"b\"\n",
+			map[string]interface{}{"rules": []interface{}{
+				map[string]interface{}{"id": "a", "allowlist": map[string]interface{}{"x": 1.0}},
+				map[string]interface{}{"id": "b"},
+			}}},
+		{"quoted key", `"my key" = 1`,
+			map[string]interface{}{"my key": 1.0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{"duplicate key", "a = 1\na = 2"},
+		{"unterminated string", "a = \"open\nb = 1"},
+		{"unterminated multi-line string", "a = '''open"},
+		{"invalid escape", `a = "\q"`},
+		{"unsupported value", "a = 1979-05-27"},
+		{"missing comma", "a = [1 2]"},
+		{"table over a value", "a = 1\n[a]\nb = 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := Parse(tt.doc); err == nil {
+				t.Errorf("Parse() = %v, want an error", got)
+			}
+		})
+	}
+}